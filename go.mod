@@ -0,0 +1,3 @@
+module github.com/XenonLab-Studio/GoZork
+
+go 1.21
@@ -23,50 +23,63 @@
 package main
 
 import (
-	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
 	"os"
+	"time"
+
+	"github.com/XenonLab-Studio/GoZork/zork"
 )
 
-// sort by string length:
-// https://mmcgrana.github.io/2012/09/go-by-example-sort-by-function.html
-type ByLength []string
+func main() {
+	worldFile := flag.String("world", "", "load the game world from this file instead of the built-in one")
+	addr := flag.String("serve", "", "run a telnet server on this address (e.g. :2323) instead of playing on the console")
+	idle := flag.Duration("idle", 15*time.Minute, "disconnect telnet players after being idle this long")
+	undoLevels := flag.Int("undo", 10, "how many turns UNDO can take back")
+	seed := flag.Int64("seed", 0, "seed for the random number generator, 0 picks one at random (except for replays)")
+	replayFile := flag.String("replay", "", "play the commands from this file and print the transcript")
+	flag.Parse()
 
-func (s ByLength) Len() int {
-	return len(s)
-}
-func (s ByLength) Swap(i, j int) {
-	s[i], s[j] = s[j], s[i]
-}
-func (s ByLength) Less(i, j int) bool {
-	return len(s[i]) < len(s[j])
-}
+	def := zork.DefaultWorldDef()
+	if *worldFile != "" {
+		var err error
+		if def, err = zork.LoadWorldFile(*worldFile); err != nil {
+			fmt.Fprintf(os.Stderr, "can't load world %v: %v\n", *worldFile, err)
+			os.Exit(1)
+		}
+	}
 
-var verbAliasMap = map[string][]string{
-	"GO NORTH":   {"N", "NORTH"},
-	"GO SOUTH":   {"S", "SOUTH"},
-	"GO WEST":    {"W", "WEST"},
-	"GO EAST":    {"E", "EAST"},
-	"GO IN":      {"IN", "INSIDE", "ENTER"},
-	"GO OUT":     {"OUT", "OUTSIDE", "LEAVE"},
-	"GO UP":      {"UP"},
-	"GO DOWN":    {"DOWN"},
-	"LOOK AT":    {"EXAMINE", "INSPECT", "X"},
-	"LOOK UNDER": {"LOOK BENEATH", "LOOK BELOW"},
-	"TAKE":       {"PICK UP", "GET"},
-	"DROP":       {"THROW"},
-	"INVENTORY":  {"I"},
-	"WAIT":       {"Z"},
-}
+	// every player gets a fresh world and its own random source
+	newPlayer := func(in io.Reader, out io.Writer) *zork.Player {
+		opts := zork.Options{UndoLevels: *undoLevels, Seed: *seed}
+		if opts.Seed == 0 && *replayFile == "" {
+			opts.Seed = time.Now().UnixNano()
+		}
+		// commands from a file are echoed so the output reads like a transcript
+		opts.Echo = *replayFile != ""
+		// telnet players would share the save files in the server directory
+		opts.NoSave = *addr != ""
+		return zork.NewPlayer(def.Build(), in, out, opts)
+	}
 
-func main() {
-	// everything goes through this buffered read/writer which
-	// would make it very easy to plug this onto a telnet server.
-	console := bufio.NewReadWriter(
-		bufio.NewReader(os.Stdin),
-		bufio.NewWriter(os.Stdout))
-	player := Player{console: console, maxPoints: 11}
-	start, trollroom := NewGameWorld()
-	player.room = start
-	player.trollai.Init(trollroom, &player)
-	player.Run()
+	if *addr != "" {
+		log.Fatal(serve(*addr, *idle, newPlayer))
+	}
+
+	input := os.Stdin
+	if *replayFile != "" {
+		file, err := os.Open(*replayFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "can't open replay: %v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	// everything goes through the player console, the telnet server in
+	// server.go gives every connection its own.
+	newPlayer(input, os.Stdout).Run()
 }
@@ -0,0 +1,173 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net"
+	"time"
+
+	"github.com/XenonLab-Studio/GoZork/zork"
+)
+
+// telnet commands, see RFC 854
+const (
+	telnetSE   = 240
+	telnetSB   = 250
+	telnetWILL = 251
+	telnetWONT = 252
+	telnetDO   = 253
+	telnetDONT = 254
+	telnetIAC  = 255
+)
+
+// states of the telnet input parser
+const (
+	telnetData = iota
+	telnetCommand
+	telnetOption
+	telnetSub
+	telnetSubCommand
+)
+
+// telnetConn strips telnet commands from the input, refusing every option
+// the client asks for, and converts line endings of the output. Once the
+// connection has been idle for too long, reads say goodbye and end with io.EOF.
+type telnetConn struct {
+	conn  net.Conn
+	idle  time.Duration
+	state int
+	// the command byte that came before an option byte (WILL, DO...)
+	command byte
+	buf     [512]byte
+}
+
+func (t *telnetConn) Read(p []byte) (int, error) {
+	for {
+		if t.idle > 0 {
+			t.conn.SetReadDeadline(time.Now().Add(t.idle))
+		}
+		max := len(p)
+		if max > len(t.buf) {
+			max = len(t.buf)
+		}
+		n, err := t.conn.Read(t.buf[:max])
+		if ne, ok := err.(net.Error); ok && ne.Timeout() {
+			t.conn.Write([]byte("\r\nYou have been idle for too long, goodbye.\r\n"))
+			// the player knows why, end the game as if they hung up
+			err = io.EOF
+		}
+		count := 0
+		for _, b := range t.buf[:n] {
+			if t.filter(b) {
+				p[count] = b
+				count++
+			}
+		}
+		if count > 0 || err != nil {
+			return count, err
+		}
+	}
+}
+
+// feed a byte to the telnet parser, returns true if it is part of the data
+func (t *telnetConn) filter(b byte) bool {
+	switch t.state {
+	case telnetCommand:
+		switch b {
+		case telnetIAC:
+			// escaped 255 data byte
+			t.state = telnetData
+			return true
+		case telnetWILL, telnetWONT, telnetDO, telnetDONT:
+			t.command = b
+			t.state = telnetOption
+		case telnetSB:
+			t.state = telnetSub
+		default:
+			t.state = telnetData
+		}
+	case telnetOption:
+		// we don't support any options:
+		switch t.command {
+		case telnetWILL:
+			t.conn.Write([]byte{telnetIAC, telnetDONT, b})
+		case telnetDO:
+			t.conn.Write([]byte{telnetIAC, telnetWONT, b})
+		}
+		t.state = telnetData
+	case telnetSub:
+		if b == telnetIAC {
+			t.state = telnetSubCommand
+		}
+	case telnetSubCommand:
+		if b == telnetSE {
+			t.state = telnetData
+		} else {
+			t.state = telnetSub
+		}
+	default:
+		if b == telnetIAC {
+			t.state = telnetCommand
+			return false
+		}
+		// telnet ends lines with CR LF or CR NUL
+		return b != '\r' && b != 0
+	}
+	return false
+}
+
+func (t *telnetConn) Write(p []byte) (int, error) {
+	out := bytes.Replace(p, []byte{telnetIAC}, []byte{telnetIAC, telnetIAC}, -1)
+	out = bytes.Replace(out, []byte("\n"), []byte("\r\n"), -1)
+	if _, err := t.conn.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// accept telnet connections, every connection plays in its own world
+func serve(addr string, idle time.Duration, newPlayer func(io.Reader, io.Writer) *zork.Player) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("serving GOZORK on %v", listener.Addr())
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go playSession(conn, idle, newPlayer)
+	}
+}
+
+func playSession(conn net.Conn, idle time.Duration, newPlayer func(io.Reader, io.Writer) *zork.Player) {
+	defer conn.Close()
+	log.Printf("%v connected", conn.RemoteAddr())
+	telnet := &telnetConn{conn: conn, idle: idle}
+	newPlayer(telnet, telnet).Run()
+	log.Printf("%v disconnected", conn.RemoteAddr())
+}
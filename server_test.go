@@ -0,0 +1,99 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// a connection that keeps what the server sends back
+type recordConn struct {
+	net.Conn
+	sent bytes.Buffer
+}
+
+func (c *recordConn) Write(p []byte) (int, error) {
+	return c.sent.Write(p)
+}
+
+func TestTelnetFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    []byte
+		data  string
+		reply []byte
+	}{
+		{"text", []byte("look\r\n"), "look\n", nil},
+		{"cr nul", []byte("a\r\x00b"), "ab", nil},
+		{"escaped iac", []byte{'a', telnetIAC, telnetIAC, 'b'}, "a\xffb", nil},
+		{"refuse will", []byte{telnetIAC, telnetWILL, 24, 'x'}, "x", []byte{telnetIAC, telnetDONT, 24}},
+		{"refuse do", []byte{telnetIAC, telnetDO, 1, 'x'}, "x", []byte{telnetIAC, telnetWONT, 1}},
+		{"ignore wont and dont", []byte{telnetIAC, telnetWONT, 1, telnetIAC, telnetDONT, 1, 'x'}, "x", nil},
+		{"other command", []byte{telnetIAC, 241, 'x'}, "x", nil},
+		{"skip subnegotiation", []byte{'a', telnetIAC, telnetSB, 24, 'b', 'c', telnetIAC, telnetSE, 'd'}, "ad", nil},
+		{"iac in subnegotiation", []byte{telnetIAC, telnetSB, 24, telnetIAC, telnetIAC, 'b', telnetIAC, telnetSE, 'x'}, "x", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conn := &recordConn{}
+			telnet := &telnetConn{conn: conn}
+			var data []byte
+			for _, b := range test.in {
+				if telnet.filter(b) {
+					data = append(data, b)
+				}
+			}
+			if string(data) != test.data {
+				t.Errorf("data %q, want %q", data, test.data)
+			}
+			if !bytes.Equal(conn.sent.Bytes(), test.reply) {
+				t.Errorf("reply %v, want %v", conn.sent.Bytes(), test.reply)
+			}
+		})
+	}
+}
+
+// an idle player is told goodbye and the game ends without an error
+func TestTelnetIdle(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	go func() {
+		telnet := &telnetConn{conn: server, idle: time.Millisecond}
+		_, err := telnet.Read(make([]byte, 16))
+		if err != io.EOF {
+			t.Errorf("read error %v, want io.EOF", err)
+		}
+		server.Close()
+	}()
+	sent, err := io.ReadAll(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\r\nYou have been idle for too long, goodbye.\r\n"; string(sent) != want {
+		t.Errorf("sent %q, want %q", sent, want)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	"math/rand"
+	"strings"
+)
+
+const (
+	// hit points of the player, and how many turns it takes to heal one
+	playerHealth = 10
+	healTurns    = 5
+	// damage done without a weapon
+	fistDamage = 1
+)
+
+// hitPoints of someone who can get hurt, wounds heal over time.
+type hitPoints struct {
+	current, max int
+	// turns since the last hit point healed
+	healing int
+}
+
+func newHitPoints(max int) hitPoints {
+	return hitPoints{current: max, max: max}
+}
+
+// take damage, returns whether that was deadly
+func (hp *hitPoints) wound(damage int) bool {
+	hp.current -= damage
+	hp.healing = 0
+	return hp.current <= 0
+}
+
+// called once every turn
+func (hp *hitPoints) heal() {
+	if hp.current >= hp.max {
+		hp.healing = 0
+		return
+	}
+	if hp.healing++; hp.healing >= healTurns {
+		hp.current++
+		hp.healing = 0
+	}
+}
+
+// a few words on how hurt someone is
+func (hp *hitPoints) condition() string {
+	switch {
+	case hp.current >= hp.max:
+		return "in perfect health"
+	case hp.current*3 > hp.max*2:
+		return "lightly wounded"
+	case hp.current*3 > hp.max:
+		return "seriously wounded"
+	default:
+		return "badly hurt, one more blow could kill you"
+	}
+}
+
+// A combatRow is one possible outcome of a blow. The messages can refer to
+// {weapon} and {enemy}, one of them is picked at random.
+type combatRow struct {
+	// chance of this outcome relative to the other rows
+	weight int
+	// multiplies the damage of the weapon
+	damage int
+	// the one hit can't act for a few turns, or loses the weapon
+	stun, disarm bool
+	messages     []string
+}
+
+// A combatTable lists what can happen when someone strikes.
+type combatTable []combatRow
+
+// blows of the player against a creature
+var playerBlows = combatTable{
+	{weight: 30, damage: 0, messages: []string{
+		"Your {weapon} misses the {enemy} by an inch.",
+		"The {enemy} dodges your {weapon} with surprising speed.",
+		"You swing your {weapon} wildly, hitting nothing but air.",
+	}},
+	{weight: 35, damage: 1, messages: []string{
+		"Your {weapon} cuts the {enemy}'s arm.",
+		"You hit the {enemy} with your {weapon}, it howls in pain.",
+		"The {weapon} leaves a nasty gash in the {enemy}'s side.",
+	}},
+	{weight: 15, damage: 2, messages: []string{
+		"A mighty blow! Your {weapon} cuts deep into the {enemy}.",
+		"You drive your {weapon} into the {enemy}'s shoulder, it staggers back.",
+	}},
+	{weight: 10, damage: 1, stun: true, messages: []string{
+		"You hit the {enemy} on the head with the flat of your {weapon}, it looks dazed.",
+		"The {enemy} stumbles over its own feet trying to avoid your {weapon} and crashes to the floor.",
+	}},
+	{weight: 10, damage: 0, stun: true, disarm: true, messages: []string{
+		"Your {weapon} knocks the weapon out of the {enemy}'s hand, it stares at its empty fist in disbelief!",
+		"With a quick twist of your {weapon} you send the {enemy}'s weapon flying, it looks around confused.",
+	}},
+}
+
+// blows of the troll against the player
+var trollBlows = combatTable{
+	{weight: 35, damage: 0, messages: []string{
+		"The troll swings his {weapon} at you, but misses.",
+		"The troll's {weapon} whooshes over your head.",
+		"You jump aside just in time, the troll's {weapon} hits the floor.",
+	}},
+	{weight: 45, damage: 1, messages: []string{
+		"The troll hits your shoulder with his {weapon}.",
+		"The troll's {weapon} catches you in the ribs.",
+		"The troll knocks you against the wall with his {weapon}.",
+	}},
+	{weight: 20, damage: 2, messages: []string{
+		"The troll's {weapon} smashes into your side, something cracks!",
+		"The troll brings down his {weapon} on your head, you see stars.",
+	}},
+}
+
+// pick an outcome, leaving out disarming when there is nothing to disarm
+func (t combatTable) roll(rng *rand.Rand, armed bool) *combatRow {
+	total := 0
+	for _, row := range t {
+		if armed || !row.disarm {
+			total += row.weight
+		}
+	}
+	n := rng.Intn(total)
+	for i := range t {
+		if !armed && t[i].disarm {
+			continue
+		}
+		if n -= t[i].weight; n < 0 {
+			return &t[i]
+		}
+	}
+	return &t[len(t)-1]
+}
+
+// one of the messages of the row with the names filled in
+func (row *combatRow) message(rng *rand.Rand, weapon, enemy string) string {
+	msg := row.messages[rng.Intn(len(row.messages))]
+	return strings.NewReplacer("{weapon}", weapon, "{enemy}", enemy).Replace(msg)
+}
+
+// damage a weapon does, bare hands for nil
+func weaponDamage(weapon *Object) int {
+	if weapon == nil {
+		return fistDamage
+	}
+	return weapon.damage
+}
+
+// the first object that can be used as a weapon
+func (c *ObjectContainer) weapon() *Object {
+	for _, obj := range c.objects {
+		if obj.damage > 0 {
+			return obj
+		}
+	}
+	return nil
+}
+
+// the player gets hit, dying from it when there are no hit points left
+func (p *Player) wound(damage int) {
+	if damage > 0 && p.hp.wound(damage) {
+		p.Println("It appears that that last blow was too much for you.")
+		p.Die()
+	}
+}
+
+func (p *Player) Diagnose() bool {
+	p.Printf("You are %v.\n", p.hp.condition())
+	if p.hp.current < p.hp.max {
+		p.Println("Your wounds will heal if you rest a while.")
+	}
+	return true
+}
@@ -0,0 +1,28 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+// UnregisterNPC removes a kind of NPC a test registered.
+func UnregisterNPC(kind string) {
+	delete(npcKinds, kind)
+}
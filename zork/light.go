@@ -0,0 +1,118 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+// turns left when the player is warned that a light is running out
+const lowFuel = 10
+
+// true if the player can see, because the room isn't dark or there is a
+// light source in the room or in the player inventory that is on.
+func (p *Player) lit() bool {
+	if !p.room.dark {
+		return true
+	}
+	for _, obj := range append(p.room.Reachable(), p.Reachable()...) {
+		if obj.lit {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Player) TurnOn(args []string) bool {
+	obj := p.FindNearObject(args)
+	switch {
+	case obj == nil:
+		p.notHere(args)
+	case !obj.light:
+		p.refuse("You can't turn that on.")
+	case obj.lit:
+		p.refuse("The %v is already on.", obj.name)
+	case obj.fuel == 0:
+		p.refuse("The %v has run out of power.", obj.name)
+	default:
+		dark := !p.lit()
+		obj.lit = true
+		p.Printf("The %v is now on.\n", obj.name)
+		if dark {
+			p.Look(true)
+		}
+	}
+	return true
+}
+
+func (p *Player) TurnOff(args []string) bool {
+	obj := p.FindNearObject(args)
+	switch {
+	case obj == nil:
+		p.notHere(args)
+	case !obj.light:
+		p.refuse("You can't turn that off.")
+	case !obj.lit:
+		p.refuse("The %v is already off.", obj.name)
+	default:
+		obj.lit = false
+		p.Printf("The %v is now off.\n", obj.name)
+		if !p.lit() {
+			p.Println("It is now pitch black.")
+		}
+	}
+	return true
+}
+
+// lights that are on use up fuel every turn, a negative fuel never runs out
+func (p *Player) burnLights() {
+	for _, od := range p.world.def.Objects {
+		obj := p.world.objects[od.ID]
+		if !obj.lit || obj.fuel < 0 {
+			continue
+		}
+		obj.fuel--
+		// only the player's own lights are worth a warning
+		if !p.scope().Contains(obj) {
+			if obj.fuel == 0 {
+				obj.lit = false
+			}
+			continue
+		}
+		if obj.fuel == lowFuel {
+			p.Printf("The %v is getting dim.\n", obj.name)
+		} else if obj.fuel == 0 {
+			obj.lit = false
+			p.Printf("The %v has run out of power.\n", obj.name)
+			if !p.lit() {
+				p.Println("It is now pitch black.")
+			}
+		}
+	}
+}
+
+// walking around in the dark is dangerous
+func (p *Player) eatenByGrue() bool {
+	if p.lit() || p.rng.Intn(100) >= 25 {
+		return false
+	}
+	p.Println("Oh, no! You have walked into the slavering fangs of a lurking grue!")
+	p.Die()
+	return true
+}
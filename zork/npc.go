@@ -0,0 +1,208 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// An NPC is a creature living in the world, acting on its own every turn and
+// reacting to what the player does nearby.
+type NPC interface {
+	// unique key used to refer to the NPC in save files
+	ID() string
+	// the object standing for the NPC in the room it is in
+	Object() *Object
+	Room() *Room
+	// called once every turn, after the player acted
+	Turn(p *Player)
+	// the player came into the room of the NPC, or left it for another room
+	PlayerEntered(p *Player)
+	PlayerLeft(p *Player, to *Room)
+	// an object was put down on the floor of the room of the NPC
+	Dropped(p *Player, obj *Object)
+	// the player talks to the NPC, about a topic if there are any words
+	Talk(p *Player, topic []string)
+	// the state of the NPC for save files, and restoring it in a fresh world
+	SaveState() json.RawMessage
+	LoadState(world *World, data json.RawMessage) error
+}
+
+// NPCs can react to more than the NPC interface by implementing these.
+type (
+	// the player gives an object to the NPC
+	receiver interface {
+		Given(p *Player, obj *Object)
+	}
+	// the player throws an object at the NPC, it already lies on the floor
+	target interface {
+		ThrownAt(p *Player, obj *Object)
+	}
+	// the player attacks the NPC, the weapon is nil for bare hands
+	fighter interface {
+		Attacked(p *Player, weapon *Object)
+	}
+)
+
+// NPCBase can be embedded to implement the parts of NPC that are the same for
+// most NPCs, the hooks do nothing.
+type NPCBase struct {
+	id   string
+	obj  Object
+	room *Room
+}
+
+// NewNPCBase gives an NPC its id and the object standing for it, described
+// like the objects of a world file. Place the NPC with MoveTo.
+func NewNPCBase(id string, def ObjectDef) NPCBase {
+	return NPCBase{id: id, obj: *newObject(&def)}
+}
+
+func (n *NPCBase) ID() string                     { return n.id }
+func (n *NPCBase) Object() *Object                { return &n.obj }
+func (n *NPCBase) Room() *Room                    { return n.room }
+func (n *NPCBase) Turn(p *Player)                 {}
+func (n *NPCBase) PlayerEntered(p *Player)        {}
+func (n *NPCBase) PlayerLeft(p *Player, to *Room) {}
+func (n *NPCBase) Dropped(p *Player, obj *Object) {}
+
+func (n *NPCBase) Talk(p *Player, topic []string) {
+	p.Printf("The %v doesn't answer.\n", n.obj.name)
+}
+
+// by default only the room of the NPC is saved
+func (n *NPCBase) SaveState() json.RawMessage {
+	data, _ := json.Marshal(n.room.id)
+	return data
+}
+
+func (n *NPCBase) LoadState(world *World, data json.RawMessage) error {
+	id := ""
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+	room := world.rooms[id]
+	if room == nil {
+		return errors.New("save refers to an unknown room")
+	}
+	n.MoveTo(room)
+	return nil
+}
+
+// MoveTo places the NPC in a room, taking it out of the one it was in.
+func (n *NPCBase) MoveTo(room *Room) {
+	if n.room != nil && n.room.Contains(&n.obj) {
+		n.room.RemoveObject(&n.obj)
+	}
+	room.AddObject(&n.obj)
+	n.room = room
+}
+
+// FindPath gives the shortest way from one room to another along exits that
+// can be used, canPass decides which exits the NPC fits through and which
+// rooms it is willing to enter. Returns the rooms on the way, nil if there is
+// none.
+func FindPath(from, to *Room, canPass func(exit *Exit) bool) []*Room {
+	// the room each room was first reached from
+	came := map[*Room]*Room{from: nil}
+	queue := []*Room{from}
+	for len(queue) > 0 && came[to] == nil && from != to {
+		room := queue[0]
+		queue = queue[1:]
+		for _, dir := range room.ExitNames() {
+			next := room.ExitDirection(dir)
+			if _, seen := came[next]; next == nil || seen || !canPass(room.exits[dir]) {
+				continue
+			}
+			came[next] = room
+			queue = append(queue, next)
+		}
+	}
+	if came[to] == nil {
+		return nil
+	}
+	way := []*Room{}
+	for room := to; room != from; room = came[room] {
+		way = append([]*Room{room}, way...)
+	}
+	return way
+}
+
+// the kinds of NPCs a world file can place, by the name used in the file
+var npcKinds = map[string]func(id string, room *Room) NPC{}
+
+// RegisterNPC makes a kind of NPC available to world files, create places a
+// new NPC in a room. Register kinds before building a world, from an init
+// function for instance.
+func RegisterNPC(kind string, create func(id string, room *Room) NPC) {
+	npcKinds[kind] = create
+}
+
+// the NPC standing for an object, nil if the object isn't a NPC
+func (w *World) NPCFor(obj *Object) NPC {
+	for _, npc := range w.npcs {
+		if npc.Object() == obj {
+			return npc
+		}
+	}
+	return nil
+}
+
+// tick every NPC once, stopping when the game is over
+func (w *World) Tick(p *Player) {
+	for _, npc := range w.npcs {
+		if p.dead || p.win {
+			return
+		}
+		npc.Turn(p)
+	}
+}
+
+// tell the NPCs the player moved from one room to another
+func (w *World) playerMoved(p *Player, from *Room) {
+	// the NPCs that were already there, not the ones following the player
+	entered := []NPC{}
+	for _, npc := range w.npcs {
+		if npc.Room() == p.room {
+			entered = append(entered, npc)
+		}
+	}
+	for _, npc := range w.npcs {
+		if npc.Room() == from {
+			npc.PlayerLeft(p, p.room)
+		}
+	}
+	for _, npc := range entered {
+		npc.PlayerEntered(p)
+	}
+}
+
+// tell the NPCs in the room an object was put down
+func (w *World) dropped(p *Player, obj *Object) {
+	for _, npc := range w.npcs {
+		if npc.Room() == p.room {
+			npc.Dropped(p, obj)
+		}
+	}
+}
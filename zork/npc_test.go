@@ -0,0 +1,88 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/XenonLab-Studio/GoZork/zork"
+)
+
+// a parrot that only exists in this test, to show other packages can add
+// NPCs without changes to the game
+type parrot struct {
+	zork.NPCBase
+	heard string
+}
+
+func (n *parrot) PlayerEntered(p *zork.Player) {
+	p.Println("The parrot squawks hello.")
+}
+
+func (n *parrot) Dropped(p *zork.Player, obj *zork.Object) {
+	n.heard = obj.Name()
+}
+
+func (n *parrot) Talk(p *zork.Player, topic []string) {
+	p.Printf("The parrot squawks: %v! %v!\n", n.heard, n.heard)
+}
+
+func (n *parrot) SaveState() json.RawMessage {
+	data, _ := json.Marshal(n.heard)
+	return data
+}
+
+func (n *parrot) LoadState(world *zork.World, data json.RawMessage) error {
+	return json.Unmarshal(data, &n.heard)
+}
+
+func TestNPC(t *testing.T) {
+	zork.RegisterNPC("parrot", func(id string, room *zork.Room) zork.NPC {
+		n := &parrot{heard: "Cracker"}
+		n.NPCBase = zork.NewNPCBase(id, zork.ObjectDef{Name: "Parrot", Creature: true})
+		n.MoveTo(room)
+		return n
+	})
+	defer zork.UnregisterNPC("parrot")
+	def := zork.DefaultWorldDef()
+	def.NPCs = append(def.NPCs, zork.NPCDef{Kind: "parrot", Room: "kitchen"})
+	script := "n\ne\nopen window\nin\ntalk to parrot\ntake can\ndrop it\nundo\nask parrot about can\ndrop can\ntalk to parrot\n"
+	var out bytes.Buffer
+	zork.NewPlayer(def.Build(), strings.NewReader(script), &out, zork.Options{UndoLevels: 10, Seed: 1}).Run()
+	for _, want := range []string{
+		"The parrot squawks hello.",
+		"The parrot squawks: Cracker! Cracker!",
+		"The parrot squawks: Can! Can!",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("transcript doesn't contain %q:\n%v", want, out.String())
+		}
+	}
+	// once before the can was dropped, once after UNDO took it back
+	if strings.Count(out.String(), "Cracker!") != 4 {
+		t.Errorf("undo didn't restore what the parrot heard:\n%v", out.String())
+	}
+}
@@ -0,0 +1,314 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Object struct {
+	// unique key used to refer to the object in save files
+	id         string
+	name       string
+	desc       string
+	adjectives []string
+	aliases    []string
+	verbs      map[string]func(*Object, *Player)
+	openable   bool
+	open       bool
+	// a locked object can't be opened until it is unlocked with its key
+	locked bool
+	key    *Object
+	// only something small fits through a narrow door, like a window
+	narrow bool
+	// whether or not this object should be mentioned below the room description
+	fixture bool
+	// if this object can be picked up by the player
+	carryable bool
+	// creatures are referred to as HIM or HER
+	creature bool
+	// containers hold up to capacity objects, 0 means it isn't a container
+	capacity int
+	// the objects sit on top of a surface instead of inside, always in sight
+	surface bool
+	// light sources can be turned on, burning fuel every turn
+	light bool
+	lit   bool
+	fuel  int
+	// weapons do this much damage with a hit, 0 for anything else
+	damage int
+	// the contents of a container or surface
+	ObjectContainer
+}
+
+// Return true if the string matches the object: the name or an alias,
+// following any number of the object adjectives in any order. Adjectives
+// alone match too.
+func (o *Object) RespondTo(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	for _, name := range append([]string{o.name}, o.aliases...) {
+		noun := strings.Fields(strings.ToUpper(name))
+		if len(noun) <= len(args) && strings.Join(args[len(args)-len(noun):], " ") == strings.Join(noun, " ") {
+			return o.HasAdjectives(args[:len(args)-len(noun)])
+		}
+	}
+	return o.HasAdjectives(args)
+}
+
+// Return true if all words are adjectives of the object.
+func (o *Object) HasAdjectives(words []string) bool {
+	for _, word := range words {
+		found := false
+		for _, adj := range o.adjectives {
+			if word == strings.ToUpper(adj) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if other objects can be put inside.
+func (o *Object) IsContainer() bool {
+	return o.capacity > 0 && !o.surface
+}
+
+// Return true if other objects can be put on top.
+func (o *Object) IsSurface() bool {
+	return o.capacity > 0 && o.surface
+}
+
+// Return true if the contents of a container can be seen and reached, which
+// is when it is open or can't be closed at all. What is on a surface always
+// can.
+func (o *Object) ContentsVisible() bool {
+	return o.IsSurface() || o.IsContainer() && (o.open || !o.openable)
+}
+
+// Name is what the object is called, without an article or its state.
+func (o *Object) Name() string {
+	return o.name
+}
+
+func (o *Object) GetName() string {
+	res := "a " + o.name
+	if o.openable {
+		if o.open {
+			res += " (open)"
+		} else {
+			res += " (closed)"
+		}
+	}
+	if o.lit {
+		res += " (providing light)"
+	}
+	if o.IsSurface() && len(o.objects) > 0 {
+		res += " with " + o.contentNames() + " on it"
+	} else if o.ContentsVisible() && len(o.objects) > 0 {
+		res += " containing " + o.contentNames()
+	}
+	return res
+}
+
+// the names of the contents, joined like an object list
+func (o *Object) contentNames() string {
+	names := []string{}
+	for _, obj := range o.objects {
+		names = append(names, obj.GetName())
+	}
+	return joinNames(names)
+}
+
+func (o *Object) GetDesc() string {
+	res := ""
+	if len(o.desc) > 0 {
+		res = o.desc
+		if o.openable {
+			res += "\n"
+		}
+	}
+	if o.openable {
+		if o.open {
+			res += fmt.Sprintf("The %v is open.", o.name)
+		} else {
+			res += fmt.Sprintf("The %v is closed.", o.name)
+		}
+	}
+	if o.light {
+		if len(res) > 0 {
+			res += "\n"
+		}
+		if o.lit {
+			res += fmt.Sprintf("The %v is on.", o.name)
+		} else {
+			res += fmt.Sprintf("The %v is off.", o.name)
+		}
+	}
+	// an empty surface is not worth mentioning
+	if o.ContentsVisible() && (o.IsContainer() || len(o.objects) > 0) {
+		if len(res) > 0 {
+			res += "\n"
+		}
+		res += o.ContentsDesc()
+	}
+	return res
+}
+
+// what is inside a container or on a surface, for LOOK IN
+func (o *Object) ContentsDesc() string {
+	if o.IsSurface() {
+		switch len(o.objects) {
+		case 0:
+			return fmt.Sprintf("There is nothing on the %v.", o.name)
+		case 1:
+			return fmt.Sprintf("On the %v is %v.", o.name, o.contentNames())
+		}
+		return fmt.Sprintf("On the %v are %v.", o.name, o.contentNames())
+	}
+	if len(o.objects) == 0 {
+		return fmt.Sprintf("The %v is empty.", o.name)
+	}
+	return fmt.Sprintf("The %v contains %v.", o.name, o.contentNames())
+}
+
+// join names like "a, b and c"
+func joinNames(names []string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+type ObjectContainer struct {
+	objects []*Object
+}
+
+func (c *ObjectContainer) AddObject(objs ...*Object) {
+	c.objects = append(c.objects, objs...)
+}
+
+func (c *ObjectContainer) RemoveObject(obj *Object) {
+	loc := -1
+	for i, val := range c.objects {
+		if val == obj {
+			loc = i
+			break
+		}
+	}
+	c.objects = append(c.objects[:loc], c.objects[loc+1:]...)
+}
+
+// The names of the objects worth mentioning, fixtures are part of the
+// description but what lies in them is listed. What is on fixed surfaces is
+// left to SurfaceDescs.
+func (c *ObjectContainer) ObjectNames() (res string, err error) {
+	names := []string{}
+	for _, obj := range c.objects {
+		if !obj.fixture {
+			names = append(names, obj.GetName())
+		} else if obj.IsContainer() && obj.ContentsVisible() {
+			for _, content := range obj.objects {
+				names = append(names, fmt.Sprintf("%v (in the %v)", content.GetName(), obj.name))
+			}
+		}
+	}
+	if len(names) == 0 {
+		err = errors.New("no objects found")
+	}
+	return joinNames(names), err
+}
+
+func (c *ObjectContainer) Contains(obj *Object) bool {
+	for _, val := range c.objects {
+		if val == obj {
+			return true
+		}
+	}
+	return false
+}
+
+// Sentences like "On the Bed is a Trout." for the fixed surfaces that have
+// something on them.
+func (c *ObjectContainer) SurfaceDescs() []string {
+	descs := []string{}
+	for _, obj := range c.objects {
+		if obj.fixture && obj.IsSurface() && len(obj.objects) > 0 {
+			descs = append(descs, obj.ContentsDesc())
+		}
+	}
+	return descs
+}
+
+// The objects and, recursively, the contents of the containers among them
+// that can be seen.
+func (c *ObjectContainer) Reachable() []*Object {
+	objs := []*Object{}
+	for _, obj := range c.objects {
+		objs = append(objs, obj)
+		if obj.ContentsVisible() {
+			objs = append(objs, obj.Reachable()...)
+		}
+	}
+	return objs
+}
+
+// The container directly holding the object, looking inside containers
+// too, open or not. Nil if the object isn't there.
+func (c *ObjectContainer) Holder(obj *Object) *ObjectContainer {
+	for _, val := range c.objects {
+		if val == obj {
+			return c
+		}
+		if holder := val.Holder(obj); holder != nil {
+			return holder
+		}
+	}
+	return nil
+}
+
+// all objects matching the words, not just the first one
+func (c *ObjectContainer) FindObjects(args []string) []*Object {
+	objs := []*Object{}
+	for _, obj := range c.objects {
+		if obj.RespondTo(args) {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+func (c *ObjectContainer) FindObject(args []string) *Object {
+	for _, obj := range c.objects {
+		if obj.RespondTo(args) {
+			return obj
+		}
+	}
+	return nil
+}
@@ -0,0 +1,384 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+// Package zork is the GOZORK game engine: rooms, objects, the command parser,
+// the player and the troll. A game is played by building a World and a Player
+// reading commands from an io.Reader and writing to an io.Writer.
+package zork
+
+import (
+	"strings"
+)
+
+// words that don't change the meaning of a command
+var fillerWords = map[string]bool{
+	"THE":    true,
+	"A":      true,
+	"AN":     true,
+	"SOME":   true,
+	"PLEASE": true,
+}
+
+func stripFillerWords(cmd string) string {
+	words := []string{}
+	for _, word := range strings.Fields(cmd) {
+		if !fillerWords[word] {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// prepositions that mean the same as another one
+var prepositionAliases = map[string]string{
+	"INTO":   "IN",
+	"INSIDE": "IN",
+	"ONTO":   "ON",
+}
+
+// A command is a parsed sentence: VERB [direct object] [PREPOSITION indirect object]
+type command struct {
+	verb     string
+	direct   []string
+	prep     string
+	indirect []string
+}
+
+// the words of the command, as the player could have typed it
+func (c *command) words() []string {
+	words := append([]string{c.verb}, c.direct...)
+	if c.prep != "" {
+		words = append(append(words, c.prep), c.indirect...)
+	}
+	return words
+}
+
+// split the words after the verb at the first preposition the verb knows
+func parseCommand(verb *verbDef, args []string) *command {
+	c := &command{verb: verb.name, direct: args}
+	for i, word := range args {
+		for _, prep := range verb.preps {
+			if word != prep {
+				continue
+			}
+			c.direct, c.indirect = args[:i], args[i+1:]
+			c.prep = prep
+			if alias, ok := prepositionAliases[prep]; ok {
+				c.prep = alias
+			}
+			return c
+		}
+	}
+	return c
+}
+
+// pronouns and whether they refer to creatures
+var pronouns = map[string]bool{
+	"IT":   false,
+	"THEM": false,
+	"HIM":  true,
+	"HER":  true,
+}
+
+// replace a pronoun in a noun phrase by the name of what it refers to,
+// returns false (after telling the player) if that isn't possible.
+func (p *Player) replacePronoun(words []string) ([]string, bool) {
+	if len(words) != 1 {
+		return words, true
+	}
+	creature, ok := pronouns[words[0]]
+	if !ok {
+		return words, true
+	}
+	referents := []*Object{p.it}
+	if words[0] == "THEM" && len(p.them) > 0 {
+		referents = p.them
+	} else if creature {
+		referents[0] = p.him
+		// nobody was mentioned yet, but there may be somebody around
+		for _, obj := range p.room.objects {
+			if referents[0] == nil && obj.creature {
+				referents[0] = obj
+			}
+		}
+	}
+	if referents[0] == nil {
+		p.Printf("I don't know what \"%v\" refers to.\n", strings.ToLower(words[0]))
+		return nil, false
+	}
+	names := []string{}
+	for _, referent := range referents {
+		if !p.nearby(referent) {
+			p.Printf("You can't see the %v any more.\n", referent.name)
+			return nil, false
+		}
+		names = append(names, strings.ToUpper(referent.name))
+	}
+	return strings.Split(strings.Join(names, " AND "), " "), true
+}
+
+// remember the objects of a command for pronouns in later commands
+func (p *Player) rememberReferents(c *command) {
+	for _, words := range [][]string{c.indirect, c.direct} {
+		if obj := p.FindNearObject(words); obj != nil {
+			p.it = obj
+			if obj.creature {
+				p.him = obj
+			}
+		}
+	}
+}
+
+// replace the first occurrence of a whole word in a command
+func replaceWord(cmd, old, new string) string {
+	words := strings.Split(cmd, " ")
+	for i, word := range words {
+		if word == old {
+			words[i] = new
+			break
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// words that start a command without being a verb
+var commandWords = []string{"AGAIN", "G", "OOPS"}
+
+// true if a sentence starts with something the player understands as a command
+func (p *Player) startsWithVerb(sentence string) bool {
+	words := strings.Fields(sentence)
+	if len(words) > 0 && containsWord(commandWords, words[0]) {
+		return true
+	}
+	verb, _ := verbs.match(words)
+	return verb != nil
+}
+
+// split a line of input into the commands it holds. Periods at the end of a
+// word and THEN always separate commands, commas only if a verb follows
+// (otherwise they separate objects, as in TAKE CAN, TROUT). A period inside a
+// word is kept, as in SAVE GAME.SAV.
+func (p *Player) splitCommands(line string) []string {
+	words := []string{}
+	for _, word := range strings.Fields(strings.Replace(line, ",", " , ", -1)) {
+		if trimmed := strings.TrimRight(word, "."); trimmed != word {
+			if trimmed != "" {
+				words = append(words, trimmed)
+			}
+			word = "."
+		}
+		words = append(words, word)
+	}
+	commands, current := []string{}, []string{}
+	for i, word := range words {
+		if word == "." || word == "THEN" ||
+			(word == "," && p.startsWithVerb(strings.Join(words[i+1:], " "))) {
+			if len(current) > 0 {
+				commands = append(commands, strings.Join(current, " "))
+			}
+			current = []string{}
+			continue
+		}
+		current = append(current, word)
+	}
+	if len(current) > 0 || len(commands) == 0 {
+		commands = append(commands, strings.Join(current, " "))
+	}
+	return commands
+}
+
+// split an object list into ALL, the names separated by commas or AND, and
+// the names following EXCEPT or BUT that should be left out.
+func splitObjectList(words []string) (all bool, names, except [][]string) {
+	target := &names
+	current := []string{}
+	flush := func() {
+		if len(current) > 0 {
+			*target = append(*target, current)
+			current = []string{}
+		}
+	}
+	for _, word := range words {
+		switch word {
+		case "ALL", "EVERYTHING":
+			all = true
+		case ",", "AND":
+			flush()
+		case "EXCEPT", "BUT":
+			flush()
+			target = &except
+		default:
+			current = append(current, word)
+		}
+	}
+	flush()
+	return
+}
+
+// the objects of an object list, looked up in a container. ALL stands for
+// every object the filter accepts, multiple is true if the player asked for
+// more than one object. ok is false if a name wasn't found.
+func (p *Player) objectList(words []string, c *ObjectContainer, filter func(*Object) bool) (objs []*Object, multiple, ok bool) {
+	all, names, except := splitObjectList(words)
+	if !all && len(names) == 0 {
+		p.notHere(words)
+		return nil, false, false
+	}
+	if all {
+		for _, obj := range c.objects {
+			if filter(obj) {
+				objs = append(objs, obj)
+			}
+		}
+	}
+	for _, name := range names {
+		obj := c.FindObject(name)
+		if obj == nil {
+			p.notHere(name)
+			return nil, false, false
+		}
+		if !containsObject(objs, obj) {
+			objs = append(objs, obj)
+		}
+	}
+	for _, name := range except {
+		obj := p.FindNearObject(name)
+		if obj == nil {
+			p.notHere(name)
+			return nil, false, false
+		}
+		for i, val := range objs {
+			if val == obj {
+				objs = append(objs[:i], objs[i+1:]...)
+				break
+			}
+		}
+	}
+	multiple = all || len(names) > 1
+	if multiple && len(objs) > 0 {
+		p.them = objs
+	}
+	return objs, multiple, true
+}
+
+func containsObject(objs []*Object, obj *Object) bool {
+	for _, val := range objs {
+		if val == obj {
+			return true
+		}
+	}
+	return false
+}
+
+// A question asked to the player because a noun phrase in a command matched
+// several objects, the answer picks one of the candidates.
+type question struct {
+	// the command that was asked about, and the phrase in it
+	words      []string
+	phrase     []string
+	candidates []*Object
+}
+
+// all objects in the room or the player inventory matching the words
+func (p *Player) findNearObjects(words []string) []*Object {
+	return p.scope().FindObjects(words)
+}
+
+// if a noun phrase of a command refers to several objects, ask the player
+// which one was meant, returns true if a question was asked.
+func (p *Player) ambiguous(c *command) bool {
+	for _, words := range [][]string{c.direct, c.indirect} {
+		_, names, except := splitObjectList(words)
+		for _, phrase := range append(names, except...) {
+			if matches := p.findNearObjects(phrase); len(matches) > 1 {
+				p.ask(&question{words: c.words(), phrase: phrase, candidates: matches})
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *Player) ask(q *question) {
+	names := []string{}
+	for _, obj := range q.candidates {
+		// repeat the adjectives the player used
+		name := []string{"the"}
+		for _, word := range q.phrase {
+			for _, adj := range obj.adjectives {
+				if word == strings.ToUpper(adj) {
+					name = append(name, strings.ToLower(adj))
+				}
+			}
+		}
+		names = append(names, strings.Join(append(name, strings.ToLower(obj.name)), " "))
+	}
+	list := strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+	p.Printf("Which do you mean, %v?\n", list)
+	p.question = q
+}
+
+// take the input as the answer to a question, returns the command to play
+// with the chosen object, or false if there is nothing to play.
+func (p *Player) answer(q *question, input string) (string, bool) {
+	matches := []*Object{}
+	for _, obj := range q.candidates {
+		if obj.RespondTo(strings.Fields(input)) {
+			matches = append(matches, obj)
+		}
+	}
+	switch {
+	case len(matches) == 1:
+		// the name alone, or else every adjective and the name should leave
+		// no doubt
+		obj, phrase := matches[0], []string{strings.ToUpper(matches[0].name)}
+		if len(p.findNearObjects(phrase)) > 1 {
+			phrase = []string{}
+			for _, adj := range obj.adjectives {
+				phrase = append(phrase, strings.ToUpper(adj))
+			}
+			phrase = append(phrase, strings.ToUpper(obj.name))
+		}
+		return strings.Join(replaceSequence(q.words, q.phrase, phrase), " "), true
+	case len(matches) > 1:
+		p.ask(&question{words: q.words, phrase: q.phrase, candidates: matches})
+		return "", false
+	case p.startsWithVerb(input):
+		// the player moved on to something else
+		return input, true
+	default:
+		p.Println("That isn't one of them.")
+		return "", false
+	}
+}
+
+// replace the first occurrence of a sequence of words
+func replaceSequence(words, old, new []string) []string {
+	for i := 0; i+len(old) <= len(words); i++ {
+		if strings.Join(words[i:i+len(old)], " ") == strings.Join(old, " ") {
+			res := append([]string{}, words[:i]...)
+			res = append(res, new...)
+			return append(res, words[i+len(old):]...)
+		}
+	}
+	return words
+}
@@ -0,0 +1,833 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+type Player struct {
+	console *bufio.ReadWriter
+	// print commands after reading them (when they don't come from a terminal)
+	echo bool
+	// all randomness in a game comes from here so games can be replayed
+	rng       *rand.Rand
+	world     *World
+	room      *Room
+	maxPoints byte
+	points    byte
+	dead      bool
+	win       bool
+	quit      bool
+	// what IT, THEM and HIM (or HER) refer to
+	it, him *Object
+	them    []*Object
+	// the previous command for AGAIN, and the word in it OOPS should replace
+	lastCommand, badWord string
+	// the last command went wrong, commands queued after it are dropped
+	failed bool
+	// waiting for the player to say which object was meant
+	question *question
+	// how many turns UNDO can take back, and the states before those turns
+	undoLevels int
+	history    []*saveState
+	// SAVE and RESTORE are turned off
+	noSave bool
+	// directory of the save files
+	saveDir string
+	// wounds from fighting
+	hp hitPoints
+	// a player is a object container (inventory)
+	ObjectContainer
+}
+
+// Options of a game session.
+type Options struct {
+	// how many turns UNDO can take back
+	UndoLevels int
+	// seed of the random number generator, the same seed and the same
+	// commands give the same game
+	Seed int64
+	// print commands after reading them (when they don't come from a terminal)
+	Echo bool
+	// refuse SAVE and RESTORE, for players that share the files of a server
+	NoSave bool
+	// directory SAVE and RESTORE use, the current directory if empty
+	SaveDir string
+}
+
+// NewPlayer creates a game session in a world, reading commands from in and
+// writing everything the game says to out.
+func NewPlayer(world *World, in io.Reader, out io.Writer, opts Options) *Player {
+	p := &Player{
+		console:    bufio.NewReadWriter(bufio.NewReader(in), bufio.NewWriter(out)),
+		echo:       opts.Echo,
+		rng:        rand.New(rand.NewSource(opts.Seed)),
+		undoLevels: opts.UndoLevels,
+		noSave:     opts.NoSave,
+		saveDir:    opts.SaveDir,
+		hp:         newHitPoints(playerHealth),
+	}
+	p.SetWorld(world)
+	return p
+}
+
+// SetWorld places the player in the starting room of a new world.
+func (p *Player) SetWorld(world *World) {
+	p.world = world
+	p.room = world.start
+	p.maxPoints = world.def.MaxPoints
+}
+
+// verbs are mapped to Player methods:
+
+func (p *Player) Go(args []string) bool {
+	if len(args) == 0 {
+		p.refuse("Where do you want to go?")
+		return true
+	}
+	// a direction or the name of an exit, like LADDER
+	dir := strings.Join(args, " ")
+	if newRoom := p.room.ExitDirection(dir); newRoom != nil {
+		if p.eatenByGrue() {
+			return true
+		}
+		from := p.room
+		p.room.Leave()
+		p.room = newRoom
+		p.Look(!newRoom.visited)
+		newRoom.Enter()
+		p.world.playerMoved(p, from)
+	} else if reason := p.room.Blocked(dir); reason != "" {
+		p.refuse("%v", reason)
+	} else {
+		p.refuse("You can't go in that direction.")
+	}
+	return true
+}
+
+// list the exits of the room, where they lead or why they are blocked
+func (p *Player) Exits() bool {
+	if !p.lit() {
+		p.refuse("It's too dark to see!")
+		return true
+	}
+	names := p.room.ExitNames()
+	if len(names) == 0 {
+		p.Println("There is no way out of here.")
+		return true
+	}
+	p.Println("Exits:")
+	for _, dir := range names {
+		if reason := p.room.Blocked(dir); reason != "" {
+			p.Printf("  %v: %v\n", dir, reason)
+		} else if to := p.room.ExitDirection(dir); to.visited {
+			p.Printf("  %v: %v\n", dir, strings.TrimSpace(to.name))
+		} else {
+			p.Printf("  %v: unexplored\n", dir)
+		}
+	}
+	return true
+}
+
+func (p *Player) Look(printDesc bool) bool {
+	if !p.lit() {
+		p.Println("It is pitch black. You are likely to be eaten by a grue.")
+		return true
+	}
+	p.Println(p.room.name)
+	if printDesc && p.room.desc != "" {
+		p.Println(p.room.desc)
+	}
+	if objstr, err := p.room.ObjectNames(); err == nil {
+		p.Println("There is " + objstr + " here.")
+	}
+	for _, desc := range p.room.SurfaceDescs() {
+		p.Println(desc)
+	}
+	return true
+}
+func (p *Player) LookAt(args []string) bool {
+	if len(args) == 0 {
+		return p.Look(true)
+	}
+	if obj := p.FindNearObject(args); obj != nil {
+		p.Println(obj.GetDesc())
+	} else {
+		p.notHere(args)
+	}
+	return true
+}
+
+func (p *Player) Take(c *command) bool {
+	// things in the room, in open containers too, ALL only takes what lies around
+	source := &ObjectContainer{}
+	if p.lit() {
+		source.objects = p.room.Reachable()
+	}
+	filter := func(obj *Object) bool {
+		return obj.carryable && !obj.fixture && p.room.Contains(obj)
+	}
+	if c.prep == "FROM" {
+		from := p.FindNearObject(c.indirect)
+		if from == nil {
+			p.notHere(c.indirect)
+			return true
+		}
+		if !p.canReachInto(from) {
+			return true
+		}
+		source = &from.ObjectContainer
+		filter = func(obj *Object) bool {
+			return obj.carryable && !obj.fixture
+		}
+	}
+	objs, multiple, ok := p.objectList(c.direct, source, filter)
+	if ok && len(objs) == 0 {
+		p.refuse("There is nothing here you can take.")
+	}
+	for _, obj := range objs {
+		if p.win || p.dead {
+			// the rest doesn't matter any more
+			break
+		}
+		if multiple {
+			p.Printf("%v: ", obj.name)
+		}
+		if obj.carryable && !obj.fixture {
+			holder := p.room.Holder(obj)
+			if holder == nil {
+				// out of a container the player carries
+				holder = p.Holder(obj)
+			}
+			holder.RemoveObject(obj)
+			p.AddObject(obj)
+			p.Println("Taken.")
+		} else {
+			p.refuse("This can't be taken.")
+		}
+	}
+	return true
+}
+
+func (p *Player) Push(obj *Object) bool {
+	if obj != nil {
+		if cb := obj.verbs["PUSH"]; cb != nil {
+			cb(obj, p)
+		} else {
+			p.refuse("You can't push this.")
+		}
+	} else {
+		p.refuse("I don't know what you are referring to.")
+	}
+	return true
+}
+
+func (p *Player) Pull(obj *Object) bool {
+	if obj != nil {
+		if cb := obj.verbs["PULL"]; cb != nil {
+			cb(obj, p)
+		} else {
+			p.refuse("You can't pull this.")
+		}
+	} else {
+		p.refuse("I don't know what you are referring to.")
+	}
+	return true
+}
+
+func (p *Player) LookUnder(obj *Object) bool {
+	if obj != nil {
+		if cb := obj.verbs["LOOK UNDER"]; cb != nil {
+			cb(obj, p)
+		} else {
+			p.Println("You don't see anything out of the ordinary.")
+		}
+	} else {
+		p.refuse("I don't know what you are referring to.")
+	}
+	return true
+}
+
+func (p *Player) Drop(args []string) bool {
+	objs, multiple, ok := p.objectList(args, &p.ObjectContainer, func(obj *Object) bool {
+		return true
+	})
+	if ok && len(objs) == 0 {
+		p.refuse("You have nothing to drop.")
+	}
+	for _, obj := range objs {
+		if p.win || p.dead {
+			// the game ended on one of the objects dropped before
+			break
+		}
+		if multiple {
+			p.Printf("%v: ", obj.name)
+		}
+		p.RemoveObject(obj)
+		p.room.AddObject(obj)
+		p.Println("Dropped.")
+		p.world.dropped(p, obj)
+	}
+	return true
+}
+
+func (p *Player) LookIn(args []string) bool {
+	if obj := p.FindNearObject(args); obj == nil {
+		p.notHere(args)
+	} else if p.canReachInto(obj) {
+		p.Println(obj.ContentsDesc())
+	}
+	return true
+}
+
+// check the contents of a container or surface can be seen, telling the
+// player if not
+func (p *Player) canReachInto(obj *Object) bool {
+	if obj.capacity == 0 {
+		p.refuse("There is nothing inside the %v.", obj.name)
+		return false
+	}
+	if !obj.ContentsVisible() {
+		p.refuse("The %v is closed.", obj.name)
+		return false
+	}
+	return true
+}
+
+func (p *Player) Inventory(args []string) bool {
+	if objstr, err := p.ObjectNames(); err == nil {
+		p.Println("You are carrying " + objstr + ".")
+	} else {
+		p.Println("You are empty handed.")
+	}
+	return true
+}
+
+func (p *Player) Open(args []string) bool {
+	if obj := p.FindNearObject(args); obj != nil {
+		if obj.openable {
+			if obj.locked {
+				p.refuse("The %v is locked.", obj.name)
+			} else if !obj.open {
+				obj.open = true
+				if obj.IsContainer() && len(obj.objects) > 0 {
+					p.Printf("Opening the %v reveals %v.\n", obj.name, obj.contentNames())
+				} else {
+					p.Println("Opened.")
+				}
+			} else {
+				p.refuse("Already open.")
+			}
+		} else {
+			p.refuse("I can't open that.")
+		}
+	} else {
+		p.notHere(args)
+	}
+	return true
+}
+
+func (p *Player) Close(args []string) bool {
+	if obj := p.FindNearObject(args); obj != nil {
+		if obj.openable {
+			if obj.open {
+				obj.open = false
+				p.Println("Closed.")
+			} else {
+				p.refuse("Already closed.")
+			}
+		} else {
+			p.refuse("I can't close that.")
+		}
+	} else {
+		p.notHere(args)
+	}
+	return true
+}
+
+func (p *Player) Wait() bool {
+	p.Println("Time passes.")
+	return true
+}
+
+func (p *Player) Help(args []string) bool {
+	p.Println("\nThis is a text adventure game, the goal is to find and kill the troll.")
+	p.Println("\nThe game understands simple sentences, for instance: PICK UP HAT, OPEN DOOR or PUT COIN IN BOX. The verbs are:\n")
+	for _, verb := range verbs.list {
+		if verb.syntax == "" {
+			continue
+		}
+		line := verb.syntax + ": " + verb.help
+		if len(verb.aliases) > 0 {
+			line += " (also " + strings.Join(verb.aliases, ", ") + ")"
+		}
+		p.Println("  " + line)
+	}
+	p.Println("\nDirections are: NORTH, SOUTH, EAST, WEST, NORTHEAST, NORTHWEST, SOUTHEAST, SOUTHWEST, UP, DOWN, IN and OUT, or just N, S, E, W, NE, NW, SE, SW.")
+	p.Println("Some exits have a name of their own, like GO LADDER or GO STAIRS.")
+	p.Println("\nSeveral commands can be given at once, separated by periods, commas or THEN: OPEN WINDOW. GO IN THEN TAKE CAN")
+	p.Println("\nThe last thing you mentioned can be called IT or THEM, creatures HIM or HER.")
+	p.Println("\nAGAIN (or G) repeats your last command and OOPS followed by a word corrects a typo in it.")
+	return true
+}
+
+func (p *Player) Quit() bool {
+	p.Println("\nThanks for playing!")
+	p.quit = true
+	return true
+}
+
+func (p *Player) GivePoints(off byte) {
+	p.points += off
+	p.Printf("(\nYour score increased by %d points, you now have %d/%d points.)\n", off, p.points, p.maxPoints)
+}
+
+func (p *Player) Die() {
+	p.dead = true
+	p.Println(" **** GAME OVER! You are dead.")
+	p.Printf("You managed to score %d out of %d possible points.\n", p.points, p.maxPoints)
+}
+
+func (p *Player) Win() {
+	p.win = true
+	p.Println(" **** CONGRATULATIONS! YOU WON THE GAME!")
+	p.Printf("You managed to score %d out of %d possible points.\n", p.points, p.maxPoints)
+}
+
+// find both objects of a two object command, complaining if they aren't here
+func (p *Player) resolve(c *command) (direct, indirect *Object, ok bool) {
+	if len(c.direct) == 0 {
+		p.refuse("What do you want to %v?", strings.ToLower(c.verb))
+		return nil, nil, false
+	}
+	if direct = p.FindNearObject(c.direct); direct == nil {
+		p.notHere(c.direct)
+		return nil, nil, false
+	}
+	if c.prep == "" {
+		return direct, nil, true
+	}
+	if len(c.indirect) == 0 {
+		p.refuse("%v the %v %v what?", c.verb, direct.name, c.prep)
+		return nil, nil, false
+	}
+	if indirect = p.FindNearObject(c.indirect); indirect == nil {
+		p.notHere(c.indirect)
+		return nil, nil, false
+	}
+	return direct, indirect, true
+}
+
+// check the player is holding an object before giving it away
+func (p *Player) holding(obj *Object) bool {
+	if p.Contains(obj) {
+		return true
+	}
+	p.refuse("You aren't holding the %v.", obj.name)
+	return false
+}
+
+func (p *Player) Put(c *command) bool {
+	direct, indirect, ok := p.resolve(c)
+	if !ok {
+		return true
+	}
+	if indirect == nil {
+		p.refuse("Where do you want to put the %v?", direct.name)
+	} else if c.prep == "ON" {
+		p.putOn(direct, indirect)
+	} else if !indirect.IsContainer() {
+		p.refuse("You can't put anything in the %v.", indirect.name)
+	} else if !indirect.ContentsVisible() {
+		p.refuse("The %v is closed.", indirect.name)
+	} else if direct == indirect || direct.Holder(indirect) != nil {
+		p.refuse("You can't put the %v inside itself.", direct.name)
+	} else if len(indirect.objects) >= indirect.capacity {
+		p.refuse("There's no more room in the %v.", indirect.name)
+	} else if p.holding(direct) {
+		p.RemoveObject(direct)
+		indirect.AddObject(direct)
+		p.Println("Done.")
+	}
+	return true
+}
+
+func (p *Player) putOn(direct, indirect *Object) {
+	if !indirect.IsSurface() {
+		p.refuse("There is no good surface on the %v.", indirect.name)
+	} else if direct == indirect || direct.Holder(indirect) != nil {
+		p.refuse("You can't put the %v on itself.", direct.name)
+	} else if len(indirect.objects) >= indirect.capacity {
+		p.refuse("There's no more room on the %v.", indirect.name)
+	} else if p.holding(direct) {
+		p.RemoveObject(direct)
+		indirect.AddObject(direct)
+		p.Println("Done.")
+	}
+}
+
+func (p *Player) Give(c *command) bool {
+	direct, indirect, ok := p.resolve(c)
+	if !ok {
+		return true
+	}
+	if indirect == nil {
+		p.refuse("Who do you want to give the %v to?", direct.name)
+	} else if p.holding(direct) {
+		if npc, ok := p.world.NPCFor(indirect).(receiver); ok {
+			npc.Given(p, direct)
+		} else {
+			p.refuse("The %v doesn't want the %v.", indirect.name, direct.name)
+		}
+	}
+	return true
+}
+
+func (p *Player) Throw(c *command) bool {
+	// without a target throwing is just dropping
+	if c.prep == "" {
+		return p.Drop(c.direct)
+	}
+	direct, indirect, ok := p.resolve(c)
+	if !ok || !p.holding(direct) {
+		return true
+	}
+	p.RemoveObject(direct)
+	p.room.AddObject(direct)
+	if npc, ok := p.world.NPCFor(indirect).(target); ok {
+		npc.ThrownAt(p, direct)
+	} else {
+		p.Printf("The %v hits the %v and falls to the floor.\n", direct.name, indirect.name)
+	}
+	p.world.dropped(p, direct)
+	return true
+}
+
+func (p *Player) Unlock(c *command) bool {
+	direct, indirect, ok := p.resolve(c)
+	if !ok {
+		return true
+	}
+	if !direct.locked {
+		p.refuse("The %v isn't locked.", direct.name)
+	} else if p.fits(c, direct, indirect) {
+		direct.locked = false
+		p.Println("Unlocked.")
+	}
+	return true
+}
+
+func (p *Player) Lock(c *command) bool {
+	direct, indirect, ok := p.resolve(c)
+	if !ok {
+		return true
+	}
+	if direct.key == nil {
+		p.refuse("The %v can't be locked.", direct.name)
+	} else if direct.locked {
+		p.refuse("The %v is already locked.", direct.name)
+	} else if direct.open {
+		p.refuse("You have to close the %v first.", direct.name)
+	} else if p.fits(c, direct, indirect) {
+		direct.locked = true
+		p.Println("Locked.")
+	}
+	return true
+}
+
+// check the player holds the key of a lockable object, complaining if not
+func (p *Player) fits(c *command, obj, key *Object) bool {
+	if key == nil {
+		p.refuse("What do you want to %v the %v with?", strings.ToLower(c.verb), obj.name)
+		return false
+	}
+	if !p.holding(key) {
+		return false
+	}
+	if key != obj.key {
+		p.refuse("The %v doesn't fit the %v.", key.name, obj.name)
+		return false
+	}
+	return true
+}
+
+func (p *Player) Attack(c *command) bool {
+	direct, indirect, ok := p.resolve(c)
+	if !ok {
+		return true
+	}
+	if indirect != nil && !p.holding(indirect) {
+		return true
+	}
+	if npc, ok := p.world.NPCFor(direct).(fighter); ok {
+		npc.Attacked(p, indirect)
+	} else {
+		p.refuse("Violence isn't the answer to this one.")
+	}
+	return true
+}
+
+func (p *Player) Talk(c *command) bool {
+	if len(c.direct) == 0 {
+		p.refuse("Who do you want to talk to?")
+		return true
+	}
+	obj := p.FindNearObject(c.direct)
+	if obj == nil {
+		p.notHere(c.direct)
+	} else if npc := p.world.NPCFor(obj); npc != nil {
+		npc.Talk(p, c.indirect)
+	} else {
+		p.refuse("The %v doesn't answer, what did you expect?", obj.name)
+	}
+	return true
+}
+
+func (p *Player) FindNearObject(args []string) *Object {
+	return p.scope().FindObject(args)
+}
+
+// objects in the current room, then objects/items in the player inventory,
+// both with the contents of open containers. In the dark only what the
+// player carries can be found.
+func (p *Player) scope() *ObjectContainer {
+	if !p.lit() {
+		return &ObjectContainer{objects: p.Reachable()}
+	}
+	return &ObjectContainer{objects: append(p.room.Reachable(), p.Reachable()...)}
+}
+
+// tell the player a command can't be done, the commands queued after it are
+// dropped
+func (p *Player) refuse(format string, args ...interface{}) {
+	p.failed = true
+	p.Printf(format+"\n", args...)
+}
+
+// complain about an object that isn't here, OOPS can correct its name
+func (p *Player) notHere(words []string) {
+	p.failed = true
+	if len(words) == 0 {
+		p.Println("I don't know what you are referring to.")
+		return
+	}
+	if !p.lit() {
+		p.Println("It's too dark to see!")
+		return
+	}
+	noun := words[len(words)-1]
+	// the thing is here, but not the way the player described it
+	for _, obj := range p.findNearObjects([]string{noun}) {
+		for _, word := range words[:len(words)-1] {
+			if !obj.HasAdjectives([]string{word}) {
+				p.badWord = word
+				p.Printf("There is a %v here, but it isn't %v.\n", obj.name, strings.ToLower(word))
+				return
+			}
+		}
+	}
+	p.badWord = noun
+	p.Printf("I don't see any %v here.\n", strings.Join(words, " "))
+}
+
+// true if the object is in the room or in the player inventory
+func (p *Player) nearby(obj *Object) bool {
+	return p.scope().Contains(obj)
+}
+
+func (p *Player) ExecuteCommand(input string) bool {
+	delegated, meta := false, false
+	p.failed = false
+	before := p.snapshot()
+	if verb, args := verbs.match(strings.Fields(input)); verb != nil {
+		c := parseCommand(verb, args)
+		ok := false
+		if c.direct, ok = p.replacePronoun(c.direct); ok {
+			c.indirect, ok = p.replacePronoun(c.indirect)
+		}
+		delegated, meta = true, true
+		if !ok {
+			// understood, but it isn't clear what it is about, so no turn passes
+			p.failed = true
+		} else if !verb.meta && !p.correctNouns(c) {
+			// a word couldn't be corrected, the player was told
+		} else if p.ambiguous(c) {
+			// the player has to answer a question first
+			p.failed = true
+		} else {
+			delegated = verb.handler(p, c)
+			meta = verb.meta
+			p.rememberReferents(c)
+		}
+	}
+	if !delegated {
+		if input == "" {
+			p.failed = true
+			p.Println("Sorry, what?")
+		} else if corrected, ok := p.correctVerb(input); ok {
+			return p.ExecuteCommand(corrected)
+		}
+	} else if !meta {
+		p.remember(before)
+		p.burnLights()
+		p.hp.heal()
+		p.world.Tick(p)
+	}
+	return delegated
+}
+
+func (p *Player) Println(line string) {
+	p.Printf("%s\n", line)
+}
+
+func (p *Player) Printf(format string, args ...interface{}) {
+	line := fmt.Sprintf(format, args...)
+	p.console.WriteString(line)
+	p.console.Flush()
+}
+
+// Start greets the player and shows the starting room.
+func (p *Player) Start() {
+	p.Println("Welcome to GOZORK! Type HELP for help.")
+
+	// start the player west of the house
+	p.room.Enter()
+	p.Look(true)
+}
+
+// Command plays one line of input as if the player typed it. A line can
+// hold several commands, each of them takes a turn. Once the game is over
+// lines are ignored, a dead player can still UNDO until then.
+func (p *Player) Command(line string) {
+	if p.Over() {
+		return
+	}
+	for _, cmd := range p.splitCommands(strings.ToUpper(strings.Trim(line, "\r\n"))) {
+		if !p.command(cmd) || p.dead || p.Over() {
+			break
+		}
+	}
+}
+
+// play a single command, returns false if it went wrong
+func (p *Player) command(cmd string) bool {
+	cmd = stripFillerWords(cmd)
+
+	// the dead can only take back their last move
+	if p.dead && cmd != "UNDO" {
+		p.quit = true
+		return false
+	}
+
+	if q := p.question; q != nil {
+		p.question = nil
+		var ok bool
+		if cmd, ok = p.answer(q, cmd); !ok {
+			return false
+		}
+	}
+
+	switch {
+	case cmd == "AGAIN" || cmd == "G":
+		if p.lastCommand == "" {
+			p.Println("There is nothing to repeat.")
+			return false
+		}
+		cmd = p.lastCommand
+	case strings.Index(cmd, "OOPS ") == 0:
+		if p.badWord == "" {
+			p.Println("There was no word to replace!")
+			return false
+		}
+		cmd = replaceWord(p.lastCommand, p.badWord, strings.TrimSpace(cmd[len("OOPS "):]))
+	case cmd == "OOPS":
+		p.Println("Oops what?")
+		return false
+	}
+	p.lastCommand, p.badWord = cmd, ""
+
+	p.ExecuteCommand(cmd)
+
+	if p.dead && len(p.history) > 0 {
+		p.Println("(Type UNDO to take back your last move, anything else quits.)")
+	}
+	return !p.failed
+}
+
+// Over is true once the game has been won, lost for good or quit.
+func (p *Player) Over() bool {
+	return p.quit || p.win || (p.dead && len(p.history) == 0)
+}
+
+// Run plays a whole game reading commands from the input.
+func (p *Player) Run() {
+	p.Start()
+	for !p.Over() {
+		p.Printf(">")
+
+		// the last line of the input may come without a line end
+		line, err := p.console.ReadString('\n')
+		if line != "" {
+			if p.echo {
+				p.Printf("%s", strings.TrimSuffix(line, "\n")+"\n")
+			}
+			p.Command(line)
+		}
+		if err != nil {
+			if err != io.EOF {
+				p.Println("error reading console")
+			}
+			break
+		}
+	}
+}
+
+// Score returns the points scored so far and the maximum possible.
+func (p *Player) Score() (points, max int) {
+	return int(p.points), int(p.maxPoints)
+}
+
+// Location is the name of the room the player is in.
+func (p *Player) Location() string {
+	return strings.TrimSpace(p.room.name)
+}
+
+// Room is the room the player is in.
+func (p *Player) Room() *Room {
+	return p.room
+}
+
+// Dead is true when the player got killed, in a fight or by a grue.
+func (p *Player) Dead() bool {
+	return p.dead
+}
+
+// Won is true when the player killed the troll.
+func (p *Player) Won() bool {
+	return p.win
+}
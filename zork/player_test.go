@@ -0,0 +1,87 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/XenonLab-Studio/GoZork/zork"
+)
+
+// start a game and play the lines of a walkthrough in testdata
+func playLines(t *testing.T, name string, undoLevels int) (*zork.Player, *bytes.Buffer) {
+	script, err := os.ReadFile(filepath.Join("testdata", name+".txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	p := zork.NewPlayer(zork.NewGameWorld(), strings.NewReader(""), &out, zork.Options{UndoLevels: undoLevels, Seed: 1})
+	p.Start()
+	for _, line := range strings.Split(strings.TrimSpace(string(script)), "\n") {
+		p.Command(line)
+	}
+	return p, &out
+}
+
+func TestCommandAfterWin(t *testing.T) {
+	p, out := playLines(t, "win", 10)
+	if !p.Won() {
+		t.Fatalf("walkthrough didn't win:\n%v", out)
+	}
+	points, _ := p.Score()
+	out.Reset()
+	p.Command("undo")
+	p.Command("s. take all")
+	if out.Len() > 0 || !p.Won() {
+		t.Errorf("commands were played after winning:\n%v", out)
+	}
+	if after, _ := p.Score(); after != points {
+		t.Errorf("score changed from %v to %v after winning", points, after)
+	}
+}
+
+func TestCommandAfterDeath(t *testing.T) {
+	p, out := playLines(t, "death_grue", 10)
+	if !p.Dead() || p.Over() {
+		t.Fatalf("walkthrough didn't end in a death UNDO can take back:\n%v", out)
+	}
+	p.Command("undo")
+	if p.Dead() {
+		t.Errorf("UNDO didn't take back the death:\n%v", out)
+	}
+
+	p, out = playLines(t, "death_grue", 0)
+	if !p.Over() {
+		t.Fatalf("death without UNDO didn't end the game:\n%v", out)
+	}
+	out.Reset()
+	p.Command("undo")
+	p.Command("look")
+	if out.Len() > 0 {
+		t.Errorf("commands were played after dying:\n%v", out)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	"fmt"
+	"sort"
+)
+
+// An Exit leads out of a room, exits only go one way: the room on the other
+// side needs its own exit to come back.
+type Exit struct {
+	// the room behind it, an exit leading nowhere is always blocked
+	to *Room
+	// hidden exits can't be used or seen until they are discovered
+	hidden bool
+	// a door in the way, the exit can only be used while it is open
+	door *Object
+	// what to say when the exit can't be used
+	blocked string
+}
+
+type Room struct {
+	// unique key used to refer to the room in save files
+	id      string
+	name    string
+	desc    string
+	visited bool
+	// nothing can be seen here without a light source
+	dark bool
+	// out in the sunlight
+	outdoors bool
+	// called when a player enters this room
+	enterFunc func(*Player)
+	// room exits by direction (NORTH, NORTHEAST...) or name (LADDER)
+	exits map[string]*Exit
+	// a Room is a ObjectContainer (objects laying on the floor, or fixtures)
+	ObjectContainer
+}
+
+// To is the room behind an exit, nil if it leads nowhere.
+func (e *Exit) To() *Room {
+	return e.to
+}
+
+// the room an exit leads to, nil if there is no such exit or it is blocked
+func (r *Room) ExitDirection(dir string) *Room {
+	if r.Exit(dir) == nil || r.Blocked(dir) != "" {
+		return nil
+	}
+	return r.exits[dir].to
+}
+
+// why an exit can't be used, empty if it can (or there is no such exit)
+func (r *Room) Blocked(dir string) string {
+	exit := r.Exit(dir)
+	if exit == nil {
+		return ""
+	}
+	if exit.door != nil && !exit.door.open || exit.to == nil {
+		if exit.blocked != "" {
+			return exit.blocked
+		}
+		if exit.door != nil {
+			return fmt.Sprintf("The %v is closed.", exit.door.name)
+		}
+		return "You can't go in that direction."
+	}
+	return ""
+}
+
+// connect an exit of this room, the exit can be a direction or any name
+func (r *Room) SetExit(dir string, to *Room) *Exit {
+	if r.exits == nil {
+		r.exits = map[string]*Exit{}
+	}
+	if r.exits[dir] == nil {
+		r.exits[dir] = &Exit{}
+	}
+	r.exits[dir].to = to
+	return r.exits[dir]
+}
+
+// the exit in a direction, hidden exits are left out
+func (r *Room) Exit(dir string) *Exit {
+	if exit := r.exits[dir]; exit != nil && !exit.hidden {
+		return exit
+	}
+	return nil
+}
+
+// make hidden exits usable
+func (r *Room) Discover(dirs ...string) {
+	for _, dir := range dirs {
+		if exit := r.exits[dir]; exit != nil {
+			exit.hidden = false
+		}
+	}
+}
+
+// the exits that can be seen, directions first in compass order, then
+// named exits sorted
+func (r *Room) ExitNames() []string {
+	names := []string{}
+	for dir, exit := range r.exits {
+		if !exit.hidden {
+			names = append(names, dir)
+		}
+	}
+	order := func(dir string) int {
+		for i, d := range directions {
+			if d == dir {
+				return i
+			}
+		}
+		return len(directions)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if order(names[i]) != order(names[j]) {
+			return order(names[i]) < order(names[j])
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// put a door in the way of an exit
+func (r *Room) SetDoor(dir string, door *Object) {
+	if exit := r.exits[dir]; exit != nil {
+		exit.door = door
+	}
+}
+
+func (r *Room) Enter() {
+	r.visited = true
+}
+
+func (r *Room) Leave() {
+}
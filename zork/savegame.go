@@ -0,0 +1,350 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// bump this whenever the layout of saveState changes
+const saveVersion = 7
+
+const defaultSaveFile = "gozork.sav"
+
+// saveState is everything that can change while playing, referring to rooms
+// and objects by id so a save can be applied on top of a fresh world.
+type saveState struct {
+	Version   int      `json:"version"`
+	Room      string   `json:"room"`
+	Inventory []string `json:"inventory"`
+	Points    byte     `json:"points"`
+	// hit points of the player, since version 7
+	Health  int                    `json:"health,omitempty"`
+	Healing int                    `json:"healing,omitempty"`
+	Rooms   map[string]roomState   `json:"rooms"`
+	Objects map[string]objectState `json:"objects"`
+	// the state of every NPC by id, since version 6
+	NPCs map[string]json.RawMessage `json:"npcs"`
+	// before version 6 only the troll was saved
+	Troll *trollState `json:"troll,omitempty"`
+}
+
+type roomState struct {
+	Visited bool     `json:"visited"`
+	Desc    string   `json:"desc"`
+	Objects []string `json:"objects"`
+	// exits that weren't discovered yet, since version 5
+	Hidden []string `json:"hidden,omitempty"`
+}
+
+type objectState struct {
+	Open bool   `json:"open"`
+	Desc string `json:"desc"`
+	// contents of a container, since version 2
+	Objects []string `json:"objects,omitempty"`
+	// light sources, since version 3
+	Lit  bool `json:"lit,omitempty"`
+	Fuel int  `json:"fuel,omitempty"`
+	// since version 4
+	Locked bool `json:"locked,omitempty"`
+}
+
+// object ids of a container, leaving out objects that are not part of the
+// world (NPCs are saved on their own).
+func objectIDs(c *ObjectContainer) []string {
+	ids := []string{}
+	for _, obj := range c.objects {
+		if obj.id != "" {
+			ids = append(ids, obj.id)
+		}
+	}
+	return ids
+}
+
+func hiddenExits(room *Room) []string {
+	dirs := []string{}
+	for dir, exit := range room.exits {
+		if exit.hidden {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+func (p *Player) snapshot() *saveState {
+	state := &saveState{
+		Version:   saveVersion,
+		Room:      p.room.id,
+		Inventory: objectIDs(&p.ObjectContainer),
+		Points:    p.points,
+		Health:    p.hp.current,
+		Healing:   p.hp.healing,
+		Rooms:     map[string]roomState{},
+		Objects:   map[string]objectState{},
+		NPCs:      map[string]json.RawMessage{},
+	}
+	for _, npc := range p.world.npcs {
+		state.NPCs[npc.ID()] = npc.SaveState()
+	}
+	for id, room := range p.world.rooms {
+		state.Rooms[id] = roomState{
+			Visited: room.visited,
+			Desc:    room.desc,
+			Objects: objectIDs(&room.ObjectContainer),
+			Hidden:  hiddenExits(room),
+		}
+	}
+	for id, obj := range p.world.objects {
+		state.Objects[id] = objectState{
+			Open:    obj.open,
+			Desc:    obj.desc,
+			Objects: objectIDs(&obj.ObjectContainer),
+			Lit:     obj.lit,
+			Fuel:    obj.fuel,
+			Locked:  obj.locked,
+		}
+	}
+	return state
+}
+
+// ids of every object the save says where it is
+func (state *saveState) placed() map[string]bool {
+	ids := map[string]bool{}
+	lists := [][]string{state.Inventory}
+	for _, rs := range state.Rooms {
+		lists = append(lists, rs.Objects)
+	}
+	if state.Version >= 2 {
+		for _, objState := range state.Objects {
+			lists = append(lists, objState.Objects)
+		}
+	}
+	for _, list := range lists {
+		for _, id := range list {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// the saved objects of a room or container, followed by the objects the
+// fresh world put there that the save doesn't know about (new in the world
+// file, or NPCs)
+func restoredObjects(world *World, saved []string, fresh []*Object, placed map[string]bool) []*Object {
+	objs := world.findObjects(saved)
+	for _, obj := range fresh {
+		if !placed[obj.id] && !containsObject(objs, obj) {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// apply a saved state on top of a world, the world is expected to be freshly
+// built. Rooms or objects the save doesn't know about keep their
+// initial state.
+func (p *Player) apply(world *World, state *saveState) error {
+	if state.Version < 1 || state.Version > saveVersion {
+		return fmt.Errorf("unsupported save version %d", state.Version)
+	}
+	room := world.rooms[state.Room]
+	if room == nil {
+		return errors.New("save refers to an unknown room")
+	}
+	placed := state.placed()
+	// doors stay on both sides, older saves only have them on one
+	for _, od := range world.def.Objects {
+		if len(od.Door) > 0 {
+			delete(placed, od.ID)
+		}
+	}
+	for id, r := range world.rooms {
+		r.objects = restoredObjects(world, state.Rooms[id].Objects, r.objects, placed)
+	}
+	for id, obj := range world.objects {
+		var saved []string
+		if state.Version >= 2 {
+			saved = state.Objects[id].Objects
+		}
+		obj.objects = restoredObjects(world, saved, obj.objects, placed)
+	}
+	for id, rs := range state.Rooms {
+		if r := world.rooms[id]; r != nil {
+			r.visited = rs.Visited
+			r.desc = rs.Desc
+			if state.Version >= 5 {
+				for dir, exit := range r.exits {
+					exit.hidden = containsWord(rs.Hidden, dir)
+				}
+			}
+		}
+	}
+	for id, objState := range state.Objects {
+		if obj := world.objects[id]; obj != nil {
+			obj.open = objState.Open
+			obj.desc = objState.Desc
+			if state.Version >= 3 {
+				obj.lit, obj.fuel = objState.Lit, objState.Fuel
+			}
+			if state.Version >= 4 {
+				obj.locked = objState.Locked
+			}
+		}
+	}
+	if state.Version < 5 {
+		world.discoverEffects()
+	}
+	if state.Version < 6 && state.Troll != nil {
+		data, _ := json.Marshal(state.Troll)
+		state.NPCs = map[string]json.RawMessage{"troll": data}
+	}
+	for _, npc := range world.npcs {
+		if data, ok := state.NPCs[npc.ID()]; ok {
+			if err := npc.LoadState(world, data); err != nil {
+				return err
+			}
+		}
+	}
+	p.world = world
+	p.room = room
+	p.objects = world.findObjects(state.Inventory)
+	p.points = state.Points
+	p.hp = newHitPoints(playerHealth)
+	if state.Version >= 7 {
+		p.hp.current, p.hp.healing = state.Health, state.Healing
+	}
+	p.dead, p.win = false, false
+	p.it, p.him, p.them = nil, nil, nil
+	return nil
+}
+
+// before save version 5 hidden exits weren't saved, open the ones discovered
+// by effects that already happened (a once effect marks its object open) in
+// the room the object is in.
+func (w *World) discoverEffects() {
+	for _, od := range w.def.Objects {
+		obj := w.objects[od.ID]
+		for _, effect := range od.Verbs {
+			if !effect.Once || !obj.open || len(effect.Discover) == 0 {
+				continue
+			}
+			for _, room := range w.rooms {
+				if room.Holder(obj) != nil {
+					room.Discover(effect.Discover...)
+				}
+			}
+		}
+	}
+}
+
+// save file name from the command arguments, always in the save
+// directory so telnet players can't write anywhere else.
+func (p *Player) saveFileName(args []string) string {
+	name := defaultSaveFile
+	if len(args) > 0 {
+		name = filepath.Base(strings.ToLower(strings.Join(args, " ")))
+	}
+	return filepath.Join(p.saveDir, name)
+}
+
+// file errors name the save file without the directory it is in
+func hideSaveDir(err error) error {
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		pathErr.Path = filepath.Base(pathErr.Path)
+	}
+	return err
+}
+
+func (p *Player) Save(args []string) bool {
+	if p.noSave {
+		p.refuse("Saving isn't possible in this game.")
+		return true
+	}
+	data, err := json.MarshalIndent(p.snapshot(), "", "  ")
+	if err == nil {
+		err = os.WriteFile(p.saveFileName(args), data, 0644)
+	}
+	if err != nil {
+		p.refuse("Save failed: %v", hideSaveDir(err))
+	} else {
+		p.Println("Saved.")
+	}
+	return true
+}
+
+func (p *Player) Restore(args []string) bool {
+	if p.noSave {
+		p.refuse("Restoring isn't possible in this game.")
+		return true
+	}
+	state := &saveState{}
+	data, err := os.ReadFile(p.saveFileName(args))
+	if err == nil {
+		err = json.Unmarshal(data, state)
+	}
+	if err == nil {
+		err = p.apply(p.world.def.Build(), state)
+	}
+	if err != nil {
+		p.refuse("Restore failed: %v", hideSaveDir(err))
+		return true
+	}
+	p.Println("Restored.")
+	p.Look(true)
+	return true
+}
+
+// keep the state from before a turn, forgetting the oldest beyond undoLevels
+func (p *Player) remember(state *saveState) {
+	if p.undoLevels <= 0 {
+		return
+	}
+	p.history = append(p.history, state)
+	if len(p.history) > p.undoLevels {
+		p.history = p.history[len(p.history)-p.undoLevels:]
+	}
+}
+
+func (p *Player) Undo() bool {
+	if len(p.history) == 0 {
+		p.refuse("There is nothing to undo.")
+		return true
+	}
+	state := p.history[len(p.history)-1]
+	p.history = p.history[:len(p.history)-1]
+	if err := p.apply(p.world.def.Build(), state); err != nil {
+		p.refuse("Undo failed: %v", err)
+		return true
+	}
+	p.Println("Undone.")
+	p.Look(true)
+	return true
+}
@@ -0,0 +1,247 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	"sort"
+	"strings"
+)
+
+// directions the player can GO in
+var directions = []string{"NORTH", "SOUTH", "WEST", "EAST", "NORTHEAST", "NORTHWEST", "SOUTHEAST", "SOUTHWEST", "UP", "DOWN", "IN", "OUT"}
+
+// words in noun phrases that aren't names of things
+var phraseWords = []string{"ALL", "EVERYTHING", "AND", ",", "EXCEPT", "BUT", "IT", "THEM", "HIM", "HER"}
+
+// the number of single letter edits (insertions, deletions, substitutions
+// and swaps of neighbouring letters) to turn a into b
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// how many typos we forgive, short words are too easily mistaken for others
+func maxTypos(word string) int {
+	switch {
+	case len(word) <= 2:
+		return 0
+	case len(word) <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// true if b is a with two neighbouring letters swapped
+func swapped(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i+1 < len(a); i++ {
+		if a[i] != b[i] {
+			return a[i] == b[i+1] && a[i+1] == b[i] && a[i+2:] == b[i+2:]
+		}
+	}
+	return false
+}
+
+// the known words closest to a word that isn't known, sorted. Swapped letters
+// are the most likely typo, such a word wins over others just as close.
+func suggestions(word string, known []string) []string {
+	best, res := maxTypos(word)+1, []string{}
+	for _, candidate := range known {
+		d := editDistance(word, candidate)
+		if d < best {
+			best, res = d, []string{}
+		}
+		if d == best && d <= maxTypos(word) && !containsWord(res, candidate) {
+			res = append(res, candidate)
+		}
+	}
+	swaps := []string{}
+	for _, candidate := range res {
+		if swapped(word, candidate) {
+			swaps = append(swaps, candidate)
+		}
+	}
+	if len(swaps) > 0 {
+		res = swaps
+	}
+	sort.Strings(res)
+	return res
+}
+
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// the words the player can use to refer to objects
+func objectWords(objs []*Object) []string {
+	words := []string{}
+	for _, obj := range objs {
+		for _, name := range append(append([]string{obj.name}, obj.aliases...), obj.adjectives...) {
+			words = append(words, strings.Fields(strings.ToUpper(name))...)
+		}
+	}
+	return words
+}
+
+// tell the player about a word that isn't understood, with suggestions
+func (p *Player) unknownWord(word string, candidates []string) {
+	p.badWord = word
+	p.failed = true
+	if len(candidates) == 0 {
+		p.Printf("I don't know the word \"%v\".\n", strings.ToLower(word))
+		return
+	}
+	list := strings.ToLower(strings.Join(candidates[:len(candidates)-1], ", "))
+	if list != "" {
+		list += " or "
+	}
+	list += strings.ToLower(candidates[len(candidates)-1])
+	p.Printf("I don't know the word \"%v\". Did you mean %v?\n", strings.ToLower(word), list)
+}
+
+// correct a misspelled verb, returns the corrected input or false if the
+// verb couldn't be corrected (the player has been told).
+func (p *Player) correctVerb(input string) (string, bool) {
+	words := strings.Fields(input)
+	if len(words) == 0 {
+		return "", false
+	}
+	// the start of a verb like PICK of PICK UP, but not the rest of it
+	if containsWord(verbs.firstWords(), words[0]) {
+		p.refuse("That sentence isn't one I recognize.")
+		return "", false
+	}
+	candidates := suggestions(words[0], verbs.firstWords())
+	if len(candidates) > 1 {
+		// as close as each other, maybe only some make sense in the sentence
+		fitting := []string{}
+		for _, candidate := range candidates {
+			if p.fitsVerb(append([]string{candidate}, words[1:]...)) {
+				fitting = append(fitting, candidate)
+			}
+		}
+		if len(fitting) > 0 {
+			candidates = fitting
+		}
+	}
+	if len(candidates) != 1 {
+		p.unknownWord(words[0], candidates)
+		return "", false
+	}
+	p.Printf("(I assume you mean %v, not %v.)\n", strings.ToLower(candidates[0]), strings.ToLower(words[0]))
+	words[0] = candidates[0]
+	return strings.Join(words, " "), true
+}
+
+// true if the words following a verb suit it: verbs without objects stand
+// alone, verbs with objects need some and creatures have to be in reach to
+// talk to or fight with.
+func (p *Player) fitsVerb(words []string) bool {
+	verb, args := verbs.match(words)
+	if verb == nil {
+		return false
+	}
+	c := parseCommand(verb, args)
+	switch verb.objectKind() {
+	case "":
+		return len(c.direct) == 0
+	case "creature":
+		if len(c.direct) == 1 && pronouns[c.direct[0]] {
+			return true
+		}
+		obj := p.FindNearObject(c.direct)
+		return obj != nil && obj.creature
+	default:
+		return len(c.direct) > 0
+	}
+}
+
+// correct misspelled words in the noun phrases of a command against the
+// objects in reach, returns false if a word is too unclear to correct (the
+// player has been told).
+func (p *Player) correctNouns(c *command) bool {
+	near := append(append([]*Object{}, p.room.objects...), p.objects...)
+	// words of all things in the world are left alone, even when not in reach
+	everything := near
+	for _, npc := range p.world.npcs {
+		everything = append(everything, npc.Object())
+	}
+	for _, obj := range p.world.objects {
+		everything = append(everything, obj)
+	}
+	// named exits like LADDER too
+	exits := []string{}
+	for _, room := range p.world.rooms {
+		for dir := range room.exits {
+			exits = append(exits, strings.Fields(dir)...)
+		}
+	}
+	known := append(append(append(objectWords(everything), directions...), phraseWords...), exits...)
+	candidates := append(objectWords(near), directions...)
+	for _, dir := range p.room.ExitNames() {
+		candidates = append(candidates, strings.Fields(dir)...)
+	}
+	for _, words := range [][]string{c.direct, c.indirect} {
+		for i, word := range words {
+			if containsWord(known, word) {
+				continue
+			}
+			corrections := suggestions(word, candidates)
+			if len(corrections) > 1 {
+				p.unknownWord(word, corrections)
+				return false
+			} else if len(corrections) == 1 {
+				p.Printf("(I assume you mean %v, not %v.)\n", strings.ToLower(corrections[0]), strings.ToLower(word))
+				words[i] = corrections[0]
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,323 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// how many turns before the troll will attack the player
+const trollDifficulty = 5
+
+const (
+	trollHealth = 10
+	// a troll with this many hit points left runs away from the fight
+	trollFlee = 3
+	// turns a stunned troll can't do anything
+	trollStunTurns = 2
+)
+
+const (
+	// how many rooms the troll walks in a turn
+	trollSpeed = 1
+	// the troll loses track of a player more rooms away than this
+	trollTracking = 3
+)
+
+func init() {
+	RegisterNPC("troll", func(id string, room *Room) NPC {
+		ai := &TrollAI{aggro: trollDifficulty, hp: newHitPoints(trollHealth)}
+		ai.NPCBase = NewNPCBase(id, ObjectDef{
+			Name:       "Troll",
+			Creature:   true,
+			Desc:       "A huge, dangerous creature with sharp fanged teeth and a big broad nose. The monster is holding a heavy looking club in one of its enourmous hands.",
+			Adjectives: []string{"huge", "dangerous"},
+			Aliases:    []string{"creature", "monster"},
+		})
+		ai.MoveTo(room)
+		return ai
+	})
+}
+
+/**
+ * The troll AI is very simple, it will follow the player around the house and
+ * attack him. The player can fight back with a weapon, or drop the fish so the
+ * troll will die on food poisoning, either way the player will win.
+ */
+type TrollAI struct {
+	NPCBase
+	// indicates if the troll is chasing the player
+	follow bool
+	// turns until the troll will attack the player
+	aggro uint
+	// the troll is busy eating and won't attack this turn
+	eating bool
+	hp     hitPoints
+	// turns until the troll recovers from a blow to the head
+	stunned int
+	// killed by the player or the fish, the troll does nothing any more
+	dead bool
+}
+
+// what is saved of the troll
+type trollState struct {
+	Room   string `json:"room"`
+	Follow bool   `json:"follow"`
+	Aggro  uint   `json:"aggro"`
+	// since save version 7, a health of 0 is full health, nil objects means
+	// the troll still carries what it started with
+	Health  int      `json:"health,omitempty"`
+	Healing int      `json:"healing,omitempty"`
+	Stunned int      `json:"stunned,omitempty"`
+	Objects []string `json:"objects"`
+}
+
+func (ai *TrollAI) Turn(p *Player) {
+	if ai.dead {
+		return
+	}
+	ai.hp.heal()
+	if ai.eating {
+		ai.eating = false
+		return
+	}
+	if ai.stunned > 0 {
+		if ai.stunned--; ai.stunned == 0 && ai.room == p.room {
+			p.Println("The troll shakes his head and comes back to his senses.")
+		}
+		return
+	}
+	if ai.follow && ai.room != p.room {
+		ai.chase(p)
+	}
+	// food lying around where the troll is:
+	for _, food := range []string{"TROUT", "CAN"} {
+		if obj := ai.room.FindObject([]string{food}); obj != nil {
+			ai.eat(p, obj)
+			ai.eating = false
+			return
+		}
+	}
+	// a troll without a weapon grabs the first one it sees
+	if ai.obj.weapon() == nil {
+		if weapon := ai.room.weapon(); weapon != nil {
+			ai.room.RemoveObject(weapon)
+			ai.obj.AddObject(weapon)
+			if ai.room == p.room {
+				p.Printf("The troll picks up the %v.\n", weapon.name)
+			}
+			return
+		}
+	}
+	if ai.room != p.room {
+		return
+	}
+	if ai.aggro > 0 {
+		if ai.aggro == 3 {
+			p.Println("The troll looks at you threateningly.")
+		}
+		ai.aggro--
+		return
+	}
+	ai.strike(p)
+}
+
+// the troll attacks the player, with his bare fists if he has to
+func (ai *TrollAI) strike(p *Player) {
+	weapon := ai.obj.weapon()
+	name := "fists"
+	if weapon != nil {
+		name = weapon.name
+	}
+	row := trollBlows.roll(p.rng, false)
+	p.Println(row.message(p.rng, name, "troll"))
+	p.wound(row.damage * weaponDamage(weapon))
+}
+
+func (ai *TrollAI) Dropped(p *Player, obj *Object) {
+	if ai.dead {
+		return
+	}
+	if obj.RespondTo([]string{"TROUT"}) || obj.RespondTo([]string{"CAN"}) {
+		ai.eat(p, obj)
+	}
+}
+
+func (ai *TrollAI) eat(p *Player, food *Object) {
+	ai.eating = true
+	// win the game by giving him the fish
+	if food.RespondTo([]string{"TROUT"}) {
+		p.Println("The troll sees the fish on the floor, immediately picks it up and eats it without chewing in a single gulp.")
+		p.Println("The troll looks ill, slowly, the huge creature sinks onto the floor.")
+		p.Println("The rotten fish killed the troll, by giving him food poisoning!")
+		ai.room.RemoveObject(food)
+		ai.die(p)
+		return
+	}
+	// the can is not the solution but close:
+	p.Println("The troll sees the can on the floor, immediately picks it up and eats it without chewing in a single gulp.")
+	p.Println("Still the beast looks hungry at you.")
+	ai.room.RemoveObject(food)
+	p.GivePoints(2)
+	// this also resets the aggro counter
+	if ai.aggro < trollDifficulty/2 {
+		ai.aggro = trollDifficulty / 2
+	}
+}
+
+// walk towards the player, giving up when the player is out of reach
+func (ai *TrollAI) chase(p *Player) {
+	way := FindPath(ai.room, p.room, ai.canPass)
+	if way == nil || len(way) > trollTracking {
+		ai.follow = false
+		return
+	}
+	// reset kill turn counter
+	ai.aggro = trollDifficulty
+	for _, room := range way[:min(trollSpeed, len(way))] {
+		ai.MoveTo(room)
+	}
+	if ai.room == p.room {
+		p.Println("The monstrous creature follows you into the room!")
+	}
+}
+
+// trolls can't fit through windows, also they would turn to stone in the
+// sunlight!
+func (ai *TrollAI) canPass(exit *Exit) bool {
+	return !exit.to.outdoors && (exit.door == nil || !exit.door.narrow)
+}
+
+func (ai *TrollAI) PlayerEntered(p *Player) {
+	// player moved into the room where the troll is, follow him!
+	ai.follow = true
+}
+
+func (ai *TrollAI) Talk(p *Player, topic []string) {
+	if ai.obj.RespondTo(topic) || len(topic) == 0 {
+		p.Println("The troll grunts something you don't understand and raises his club.")
+	} else if containsWord(topic, "TROUT") || containsWord(topic, "FISH") || containsWord(topic, "FOOD") {
+		p.Println("The troll licks his lips and looks at you hungrily.")
+	} else {
+		p.Println("The troll doesn't seem to care.")
+	}
+}
+
+func (ai *TrollAI) Given(p *Player, obj *Object) {
+	// the troll won't take it from your hands, but it will see it:
+	p.RemoveObject(obj)
+	p.room.AddObject(obj)
+	p.Printf("The troll doesn't trust you, so you put the %v down in front of him.\n", obj.name)
+	p.world.dropped(p, obj)
+}
+
+func (ai *TrollAI) ThrownAt(p *Player, obj *Object) {
+	p.Printf("The %v bounces off the troll's thick skull and lands on the floor.\n", obj.name)
+}
+
+func (ai *TrollAI) Attacked(p *Player, weapon *Object) {
+	if weapon == nil {
+		p.refuse("Attacking the troll with your bare hands is suicide.")
+		return
+	}
+	if weapon.damage == 0 {
+		p.Printf("The troll easily parries your blow with the %v.\n", weapon.name)
+		return
+	}
+	// now he is really angry
+	ai.aggro, ai.follow = 0, true
+	own := ai.obj.weapon()
+	row := playerBlows.roll(p.rng, own != nil)
+	p.Println(row.message(p.rng, weapon.name, "troll"))
+	if row.disarm {
+		ai.obj.RemoveObject(own)
+		ai.room.AddObject(own)
+	}
+	if row.stun {
+		ai.stunned = trollStunTurns
+	}
+	healthy := ai.hp.current > trollFlee
+	if ai.hp.wound(row.damage * weapon.damage) {
+		p.Println("The troll staggers, drops to his knees and falls over. He won't get up again.")
+		ai.die(p)
+	} else if healthy && ai.hp.current <= trollFlee && ai.stunned == 0 {
+		ai.flee(p)
+	}
+}
+
+// the troll is gone for good, leaving what he carried behind
+func (ai *TrollAI) die(p *Player) {
+	ai.dead = true
+	ai.room.RemoveObject(&ai.obj)
+	ai.room.AddObject(ai.obj.objects...)
+	p.GivePoints(5)
+	p.Win()
+}
+
+// run from the player into a room next door, if there is a way out
+func (ai *TrollAI) flee(p *Player) {
+	for _, dir := range ai.room.ExitNames() {
+		if to := ai.room.ExitDirection(dir); to != nil && ai.canPass(ai.room.exits[dir]) {
+			p.Println("The troll, bleeding badly, turns around and flees!")
+			ai.follow = false
+			ai.MoveTo(to)
+			return
+		}
+	}
+}
+
+func (ai *TrollAI) SaveState() json.RawMessage {
+	// a struct of plain fields always marshals
+	data, _ := json.Marshal(trollState{
+		Room:    ai.room.id,
+		Follow:  ai.follow,
+		Aggro:   ai.aggro,
+		Health:  ai.hp.current,
+		Healing: ai.hp.healing,
+		Stunned: ai.stunned,
+		Objects: objectIDs(&ai.obj.ObjectContainer),
+	})
+	return data
+}
+
+func (ai *TrollAI) LoadState(world *World, data json.RawMessage) error {
+	state := trollState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	room := world.rooms[state.Room]
+	if room == nil {
+		return errors.New("save refers to an unknown room")
+	}
+	ai.MoveTo(room)
+	ai.follow, ai.aggro = state.Follow, state.Aggro
+	if state.Health > 0 {
+		ai.hp.current, ai.hp.healing = state.Health, state.Healing
+	}
+	ai.stunned = state.Stunned
+	if state.Objects != nil {
+		ai.obj.objects = world.findObjects(state.Objects)
+	}
+	return nil
+}
@@ -0,0 +1,365 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	"sort"
+	"strings"
+)
+
+// A verbDef is a verb the player can use, registered once in the verbs
+// registry below.
+type verbDef struct {
+	name string
+	// other ways to say the verb
+	aliases []string
+	// words that stand for the verb followed by some words, like N for GO NORTH
+	shortcuts map[string]string
+	// prepositions separating the direct from the indirect object
+	preps []string
+	// how to use the verb and what it does, for HELP
+	syntax string
+	help   string
+	// meta verbs don't take up a turn in the game
+	meta    bool
+	handler func(p *Player, c *command) bool
+}
+
+// the kind of the first object in the syntax, like object or creature, empty
+// for verbs that don't need one
+func (v *verbDef) objectKind() string {
+	start, end := strings.Index(v.syntax, "<"), strings.Index(v.syntax, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return v.syntax[start+1 : end]
+}
+
+// a node of the prefix tree of verb words, a match can end at any node that
+// has a verb.
+type verbNode struct {
+	children map[string]*verbNode
+	verb     *verbDef
+	// words the shortcut stands for
+	expand []string
+}
+
+type verbRegistry struct {
+	// in the order they were registered, for HELP
+	list []*verbDef
+	root *verbNode
+}
+
+func newVerbRegistry() *verbRegistry {
+	return &verbRegistry{root: &verbNode{}}
+}
+
+func (r *verbRegistry) register(def *verbDef) {
+	r.list = append(r.list, def)
+	r.add(def.name, def, nil)
+	for _, alias := range def.aliases {
+		r.add(alias, def, nil)
+	}
+	for shortcut, expand := range def.shortcuts {
+		r.add(shortcut, def, strings.Fields(expand))
+	}
+}
+
+func (r *verbRegistry) add(phrase string, def *verbDef, expand []string) {
+	node := r.root
+	for _, word := range strings.Fields(phrase) {
+		if node.children == nil {
+			node.children = map[string]*verbNode{}
+		}
+		if node.children[word] == nil {
+			node.children[word] = &verbNode{}
+		}
+		node = node.children[word]
+	}
+	node.verb, node.expand = def, expand
+}
+
+// find the longest verb phrase at the start of the words, returns the verb
+// and the words following it (nil if there is no verb).
+func (r *verbRegistry) match(words []string) (*verbDef, []string) {
+	var verb *verbDef
+	var rest []string
+	node := r.root
+	for i, word := range words {
+		if node = node.children[word]; node == nil {
+			break
+		}
+		if node.verb != nil {
+			verb = node.verb
+			rest = append(append([]string{}, node.expand...), words[i+1:]...)
+		}
+	}
+	return verb, rest
+}
+
+// the words a command can start with, sorted
+func (r *verbRegistry) firstWords() []string {
+	words := []string{}
+	for word := range r.root.children {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// every verb of the game, registered by init as HELP refers to the registry
+var verbs = newVerbRegistry()
+
+func init() {
+	for _, def := range []*verbDef{
+		{
+			name: "GO",
+			shortcuts: map[string]string{
+				"N": "NORTH", "NORTH": "NORTH",
+				"S": "SOUTH", "SOUTH": "SOUTH",
+				"W": "WEST", "WEST": "WEST",
+				"E": "EAST", "EAST": "EAST",
+				"NE": "NORTHEAST", "NORTHEAST": "NORTHEAST",
+				"NW": "NORTHWEST", "NORTHWEST": "NORTHWEST",
+				"SE": "SOUTHEAST", "SOUTHEAST": "SOUTHEAST",
+				"SW": "SOUTHWEST", "SOUTHWEST": "SOUTHWEST",
+				"IN": "IN", "INSIDE": "IN", "ENTER": "IN",
+				"OUT": "OUT", "OUTSIDE": "OUT", "LEAVE": "OUT",
+				"UP": "UP", "DOWN": "DOWN",
+			},
+			syntax:  "GO <direction>",
+			help:    "walk somewhere, the direction alone will do too",
+			handler: func(p *Player, c *command) bool { return p.Go(c.direct) },
+		},
+		{
+			name:    "LOOK",
+			aliases: []string{"L"},
+			syntax:  "LOOK",
+			help:    "describe where you are",
+			handler: func(p *Player, c *command) bool { return p.Look(true) },
+		},
+		{
+			name:    "EXITS",
+			aliases: []string{"DIRECTIONS"},
+			syntax:  "EXITS",
+			help:    "list the ways out and what is in the way",
+			handler: func(p *Player, c *command) bool { return p.Exits() },
+		},
+		{
+			name:    "LOOK AT",
+			aliases: []string{"EXAMINE", "INSPECT", "X"},
+			syntax:  "LOOK AT <object>",
+			help:    "take a closer look at something",
+			handler: func(p *Player, c *command) bool { return p.LookAt(c.direct) },
+		},
+		{
+			name:    "LOOK UNDER",
+			aliases: []string{"LOOK BENEATH", "LOOK BELOW"},
+			syntax:  "LOOK UNDER <object>",
+			help:    "see what is below something",
+			handler: func(p *Player, c *command) bool { return p.LookUnder(p.room.FindObject(c.direct)) },
+		},
+		{
+			name:    "LOOK IN",
+			aliases: []string{"LOOK INSIDE", "SEARCH"},
+			syntax:  "LOOK IN <object>",
+			help:    "see what is inside something",
+			handler: func(p *Player, c *command) bool { return p.LookIn(c.direct) },
+		},
+		{
+			name:    "TAKE",
+			aliases: []string{"PICK UP", "GET"},
+			preps:   []string{"FROM"},
+			syntax:  "TAKE <objects> [FROM <object>]",
+			help:    "pick things up, works with lists and ALL",
+			handler: func(p *Player, c *command) bool { return p.Take(c) },
+		},
+		{
+			name:    "DROP",
+			syntax:  "DROP <objects>",
+			help:    "put things you carry down, works with lists and ALL",
+			handler: func(p *Player, c *command) bool { return p.Drop(c.direct) },
+		},
+		{
+			name:    "PUT",
+			aliases: []string{"PLACE", "INSERT"},
+			preps:   []string{"IN", "INTO", "INSIDE", "ON", "ONTO"},
+			syntax:  "PUT <object> IN|ON <object>",
+			help:    "put something into or onto something else",
+			handler: func(p *Player, c *command) bool { return p.Put(c) },
+		},
+		{
+			name:    "GIVE",
+			aliases: []string{"OFFER", "HAND"},
+			preps:   []string{"TO"},
+			syntax:  "GIVE <object> TO <creature>",
+			help:    "hand something over",
+			handler: func(p *Player, c *command) bool { return p.Give(c) },
+		},
+		{
+			name:    "THROW",
+			aliases: []string{"TOSS", "HURL"},
+			preps:   []string{"AT", "TO"},
+			syntax:  "THROW <object> AT <object>",
+			help:    "throw something, without a target it is dropped",
+			handler: func(p *Player, c *command) bool { return p.Throw(c) },
+		},
+		{
+			name:    "PUSH",
+			syntax:  "PUSH <object>",
+			help:    "push or move something",
+			handler: func(p *Player, c *command) bool { return p.Push(p.FindNearObject(c.direct)) },
+		},
+		{
+			name:    "PULL",
+			syntax:  "PULL <object>",
+			help:    "pull something",
+			handler: func(p *Player, c *command) bool { return p.Pull(p.FindNearObject(c.direct)) },
+		},
+		{
+			name:    "OPEN",
+			syntax:  "OPEN <object>",
+			help:    "open a window, door or the like",
+			handler: func(p *Player, c *command) bool { return p.Open(c.direct) },
+		},
+		{
+			name:    "CLOSE",
+			syntax:  "CLOSE <object>",
+			help:    "close it again",
+			handler: func(p *Player, c *command) bool { return p.Close(c.direct) },
+		},
+		{
+			name:    "TURN ON",
+			aliases: []string{"SWITCH ON", "LIGHT"},
+			syntax:  "TURN ON <object>",
+			help:    "turn on a lamp or the like",
+			handler: func(p *Player, c *command) bool { return p.TurnOn(c.direct) },
+		},
+		{
+			name:    "TURN OFF",
+			aliases: []string{"SWITCH OFF", "EXTINGUISH"},
+			syntax:  "TURN OFF <object>",
+			help:    "turn it off again to save fuel",
+			handler: func(p *Player, c *command) bool { return p.TurnOff(c.direct) },
+		},
+		{
+			name:    "UNLOCK",
+			preps:   []string{"WITH"},
+			syntax:  "UNLOCK <object> WITH <key>",
+			help:    "unlock something",
+			handler: func(p *Player, c *command) bool { return p.Unlock(c) },
+		},
+		{
+			name:    "LOCK",
+			preps:   []string{"WITH"},
+			syntax:  "LOCK <object> WITH <key>",
+			help:    "lock it again",
+			handler: func(p *Player, c *command) bool { return p.Lock(c) },
+		},
+		{
+			name:    "TALK TO",
+			aliases: []string{"TALK", "SPEAK TO", "TALK WITH"},
+			syntax:  "TALK TO <creature>",
+			help:    "say hello",
+			handler: func(p *Player, c *command) bool { return p.Talk(c) },
+		},
+		{
+			name:    "ASK",
+			preps:   []string{"ABOUT"},
+			syntax:  "ASK <creature> ABOUT <something>",
+			help:    "ask someone about something",
+			handler: func(p *Player, c *command) bool { return p.Talk(c) },
+		},
+		{
+			name:    "ATTACK",
+			aliases: []string{"KILL", "HIT", "FIGHT", "STRIKE"},
+			preps:   []string{"WITH"},
+			syntax:  "ATTACK <creature> WITH <weapon>",
+			help:    "fight",
+			handler: func(p *Player, c *command) bool { return p.Attack(c) },
+		},
+		{
+			name:    "DIAGNOSE",
+			aliases: []string{"HEALTH"},
+			syntax:  "DIAGNOSE",
+			help:    "check your wounds",
+			handler: func(p *Player, c *command) bool { return p.Diagnose() },
+		},
+		{
+			name:    "INVENTORY",
+			aliases: []string{"I"},
+			syntax:  "INVENTORY",
+			help:    "list what you are carrying",
+			handler: func(p *Player, c *command) bool { return p.Inventory(c.direct) },
+		},
+		{
+			name:    "WAIT",
+			aliases: []string{"Z"},
+			syntax:  "WAIT",
+			help:    "let time pass",
+			handler: func(p *Player, c *command) bool { return p.Wait() },
+		},
+		{
+			name:    "XYZZY",
+			handler: func(p *Player, c *command) bool { return true },
+		},
+		{
+			name:    "SAVE",
+			syntax:  "SAVE [file]",
+			help:    "keep your progress in a file",
+			meta:    true,
+			handler: func(p *Player, c *command) bool { return p.Save(c.direct) },
+		},
+		{
+			name:    "RESTORE",
+			aliases: []string{"LOAD"},
+			syntax:  "RESTORE [file]",
+			help:    "continue a saved game",
+			meta:    true,
+			handler: func(p *Player, c *command) bool { return p.Restore(c.direct) },
+		},
+		{
+			name:    "UNDO",
+			syntax:  "UNDO",
+			help:    "take back your last move",
+			meta:    true,
+			handler: func(p *Player, c *command) bool { return p.Undo() },
+		},
+		{
+			name:    "HELP",
+			syntax:  "HELP",
+			help:    "show this help",
+			meta:    true,
+			handler: func(p *Player, c *command) bool { return p.Help(c.direct) },
+		},
+		{
+			name:    "QUIT",
+			syntax:  "QUIT",
+			help:    "stop playing",
+			meta:    true,
+			handler: func(p *Player, c *command) bool { return p.Quit() },
+		},
+	} {
+		verbs.register(def)
+	}
+}
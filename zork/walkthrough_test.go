@@ -0,0 +1,140 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden transcripts in testdata")
+
+// all walkthroughs are played with the same seed so the troll is predictable
+const walkthroughSeed = 1
+
+// play a script of commands on a fresh world and return the transcript,
+// saving in a temporary directory that holds the saves in testdata/saves
+func playScript(t *testing.T, script string) string {
+	dir := t.TempDir()
+	saves, err := filepath.Glob(filepath.Join("testdata", "saves", "*.sav"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, save := range saves {
+		data, err := os.ReadFile(save)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(dir, filepath.Base(save)), data, 0644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	var out bytes.Buffer
+	opts := Options{UndoLevels: 10, Seed: walkthroughSeed, Echo: true, SaveDir: dir}
+	NewPlayer(NewGameWorld(), strings.NewReader(script), &out, opts).Run()
+	return out.String()
+}
+
+// every testdata/NAME.txt walkthrough must produce testdata/NAME.golden
+func TestWalkthroughs(t *testing.T) {
+	scripts, err := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scripts) == 0 {
+		t.Fatal("no walkthroughs found")
+	}
+	for _, script := range scripts {
+		name := strings.TrimSuffix(filepath.Base(script), ".txt")
+		t.Run(name, func(t *testing.T) {
+			input, err := os.ReadFile(script)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := playScript(t, string(input))
+			golden := strings.TrimSuffix(script, ".txt") + ".golden"
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run go test -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("transcript differs from %v:\n%v", golden, lineDiff(string(want), got))
+			}
+		})
+	}
+}
+
+// show the first line where two transcripts differ
+func lineDiff(want, got string) string {
+	wantLines, gotLines := strings.Split(want, "\n"), strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n want: %q\n  got: %q", i+1, w, g)
+		}
+	}
+	return "(no difference)"
+}
+
+// goldens are easily regenerated, so make sure the walkthroughs still end
+// the way they are meant to.
+func TestWalkthroughOutcomes(t *testing.T) {
+	outcomes := map[string]string{
+		"win":          "YOU WON THE GAME",
+		"give":         "YOU WON THE GAME",
+		"combat":       "YOU WON THE GAME",
+		"death_troll":  "GAME OVER",
+		"death_chase":  "GAME OVER",
+		"death_can":    "GAME OVER",
+		"death_grue":   "GAME OVER",
+		"restore_v1":   "YOU WON THE GAME",
+		"restore_v4":   "YOU WON THE GAME",
+		"save_restore": "YOU WON THE GAME",
+	}
+	for name, want := range outcomes {
+		input, err := os.ReadFile(filepath.Join("testdata", name+".txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := playScript(t, string(input)); !strings.Contains(got, want) {
+			t.Errorf("%v: transcript doesn't contain %q:\n%v", name, want, got)
+		}
+	}
+}
@@ -0,0 +1,415 @@
+/*
+ *****************************************************
+ * © 2019 Stefano Peris <xenonlab.develop@gmail.com> *
+ *****************************************************
+ *
+ * Released under the GNU/GPL 3.0 license
+ *
+ * github: <https://github.com/XenonLab-Studio/GoZork>
+ *
+ *
+ * :'######::::'#######::'########::'#######::'########::'##:::'##:
+ *  ##... ##::'##.... ##:..... ##::'##.... ##: ##.... ##: ##::'##::
+ *  ##:::..::: ##:::: ##::::: ##::: ##:::: ##: ##:::: ##: ##:'##:::
+ *  ##::'####: ##:::: ##:::: ##:::: ##:::: ##: ########:: #####::::
+ *  ##::: ##:: ##:::: ##::: ##::::: ##:::: ##: ##.. ##::: ##. ##:::
+ *  ##::: ##:: ##:::: ##:: ##:::::: ##:::: ##: ##::. ##:: ##:. ##::
+ * . ######:::. #######:: ########:. #######:: ##:::. ##: ##::. ##:
+ * :......:::::.......:::........:::.......:::..:::::..::..::::..::
+ *
+ *     Textual adventure written in golang inspired by "Zork I"
+ */
+
+package zork
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// The default world, the map looks like this:
+/*                           +----------------+
+                             |                |
+        +--------------------+ North of House +-------------+
+        |                    |                |             |
+        |                    +----------------+             |
+        v                                                   |
+        +                                                   |
+        |                                                  \|/
++-------+-------+      +------------+  +---------+  +-------+------+
+|               |  +   |            |  |         |  |              |
+| West of House +--+   | Livingroom +--+ Kitchen +--+ Behind House |
+|               |  +   |            |  |         |  |              |
++-------+-------+      +------------+  +---------+  +-------+------+
+        |                                                  /|\
+        +                                                   |
+        ^                                                   |
+        |                   +----------------+              |
+        |                   |                |              |
+        +-------------------+ South of House +--------------+
+                            |                |
+                            +----------------+
+
+Below the living room a trapdoor leads down into a passage and the troll room.
+*/
+//go:embed world.json
+var defaultWorldData []byte
+
+// WorldDef is the declarative description of a world as read from a world
+// file, it is turned into rooms and objects by Build.
+type WorldDef struct {
+	Start     string      `json:"start"`
+	MaxPoints byte        `json:"maxPoints"`
+	Objects   []ObjectDef `json:"objects"`
+	Rooms     []RoomDef   `json:"rooms"`
+	NPCs      []NPCDef    `json:"npcs"`
+}
+
+// NPCDef places an NPC of a kind registered with RegisterNPC, the ID defaults
+// to the kind. The NPC carries Objects, like a weapon.
+type NPCDef struct {
+	ID      string   `json:"id"`
+	Kind    string   `json:"kind"`
+	Room    string   `json:"room"`
+	Objects []string `json:"objects"`
+}
+
+type ObjectDef struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Desc       string   `json:"desc"`
+	Adjectives []string `json:"adjectives"`
+	Aliases    []string `json:"aliases"`
+	Openable   bool     `json:"openable"`
+	Open       bool     `json:"open"`
+	// locked objects need the Key object to be unlocked
+	Locked    bool   `json:"locked"`
+	Key       string `json:"key"`
+	Fixture   bool   `json:"fixture"`
+	Carryable bool   `json:"carryable"`
+	// creatures are referred to as HIM or HER
+	Creature bool `json:"creature"`
+	// containers hold up to Capacity objects, starting with Contents, on top
+	// instead of inside for a Surface
+	Capacity int      `json:"capacity"`
+	Contents []string `json:"contents"`
+	Surface  bool     `json:"surface"`
+	// light sources can be turned on, with fuel for that many turns (0 for
+	// a light that never runs out)
+	Light bool `json:"light"`
+	Lit   bool `json:"lit"`
+	Fuel  int  `json:"fuel"`
+	// weapons do this much damage with a hit
+	Damage int `json:"damage"`
+	// a door blocks exits while closed, the directions of the exits by the
+	// id of the rooms on either side
+	Door map[string][]string `json:"door"`
+	// big creatures don't fit through a narrow door
+	Narrow bool                 `json:"narrow"`
+	Verbs  map[string]EffectDef `json:"verbs"`
+}
+
+// EffectDef is a scripted reaction of an object to a verb, the steps are
+// performed in the order of the fields.
+type EffectDef struct {
+	// only happens once, the object open flag remembers it already happened
+	Once bool   `json:"once"`
+	Say  string `json:"say"`
+	// new descriptions of the object and the room the player is in
+	Desc     string `json:"desc"`
+	RoomDesc string `json:"roomDesc"`
+	// objects that appear in the room or in the player inventory
+	Reveal []string `json:"reveal"`
+	Give   []string `json:"give"`
+	// hidden exits of the room the player is in that can be used from now on
+	Discover []string `json:"discover"`
+	Points   byte     `json:"points"`
+	// what to say when a once effect already happened
+	Else string `json:"else"`
+}
+
+type RoomDef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Desc string `json:"desc"`
+	// nothing can be seen in a dark room without a light source
+	Dark bool `json:"dark"`
+	// out in the sunlight
+	Outdoors bool `json:"outdoors"`
+	// exits by direction or name, exits only lead one way
+	Exits   map[string]ExitDef `json:"exits"`
+	Objects []string           `json:"objects"`
+}
+
+// ExitDef is an exit of a room, in a world file it is either the id of the
+// room it leads to or an object with these fields.
+type ExitDef struct {
+	To string `json:"to"`
+	// hidden until an effect discovers it
+	Hidden bool `json:"hidden"`
+	// what to say when the exit can't be used
+	Blocked string `json:"blocked"`
+}
+
+func (e *ExitDef) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &e.To); err == nil {
+		return nil
+	}
+	type exitDef ExitDef
+	return json.Unmarshal(data, (*exitDef)(e))
+}
+
+// A World holds every room and object of a game instance, indexed by id.
+type World struct {
+	// the definition the world was built from
+	def     *WorldDef
+	rooms   map[string]*Room
+	objects map[string]*Object
+	// where the player starts
+	start *Room
+	// everything that acts on its own, in the order they act
+	npcs []NPC
+}
+
+// create a game world "instance" of the default world
+func NewGameWorld() *World {
+	return DefaultWorldDef().Build()
+}
+
+// DefaultWorldDef is the definition of the built-in world.
+func DefaultWorldDef() *WorldDef {
+	def, err := ParseWorld(defaultWorldData)
+	if err != nil {
+		panic("broken default world: " + err.Error())
+	}
+	return def
+}
+
+// LoadWorldFile reads a world definition from a file.
+func LoadWorldFile(filename string) (*WorldDef, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWorld(data)
+}
+
+// ParseWorld reads a world definition from JSON and checks it for
+// references to unknown rooms and objects.
+func ParseWorld(data []byte) (*WorldDef, error) {
+	def := &WorldDef{}
+	if err := json.Unmarshal(data, def); err != nil {
+		return nil, err
+	}
+	if err := def.validate(); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// check every reference in the definition so Build can't fail
+func (d *WorldDef) validate() error {
+	objects := map[string]bool{}
+	for _, od := range d.Objects {
+		if od.ID == "" || objects[od.ID] {
+			return fmt.Errorf("missing or duplicate object id %q", od.ID)
+		}
+		objects[od.ID] = true
+	}
+	rooms := map[string]*RoomDef{}
+	for i, rd := range d.Rooms {
+		if rd.ID == "" || rooms[rd.ID] != nil {
+			return fmt.Errorf("missing or duplicate room id %q", rd.ID)
+		}
+		rooms[rd.ID] = &d.Rooms[i]
+	}
+	for _, od := range d.Objects {
+		if len(od.Contents) > od.Capacity {
+			return fmt.Errorf("object %v: more contents than capacity %d", od.ID, od.Capacity)
+		}
+		for _, id := range od.Contents {
+			if !objects[id] {
+				return fmt.Errorf("object %v: contains unknown object %q", od.ID, id)
+			}
+		}
+		if od.Key != "" && !objects[od.Key] {
+			return fmt.Errorf("object %v: unknown key %q", od.ID, od.Key)
+		}
+		for id, dirs := range od.Door {
+			if rooms[id] == nil {
+				return fmt.Errorf("object %v: door to unknown room %q", od.ID, id)
+			}
+			for _, dir := range dirs {
+				if _, ok := rooms[id].Exits[dir]; !ok {
+					return fmt.Errorf("object %v: door in front of unknown exit %v %v", od.ID, id, dir)
+				}
+			}
+		}
+		for verb, effect := range od.Verbs {
+			for _, id := range append(effect.Reveal, effect.Give...) {
+				if !objects[id] {
+					return fmt.Errorf("object %v: %v refers to unknown object %q", od.ID, verb, id)
+				}
+			}
+		}
+	}
+	for _, rd := range d.Rooms {
+		for dir, exit := range rd.Exits {
+			if exit.To != "" && rooms[exit.To] == nil {
+				return fmt.Errorf("room %v: exit %v leads to unknown room %q", rd.ID, dir, exit.To)
+			}
+		}
+		for _, id := range rd.Objects {
+			if !objects[id] {
+				return fmt.Errorf("room %v: unknown object %q", rd.ID, id)
+			}
+		}
+	}
+	if rooms[d.Start] == nil {
+		return fmt.Errorf("unknown start room %q", d.Start)
+	}
+	npcs := map[string]bool{}
+	for _, nd := range d.NPCs {
+		if npcKinds[nd.Kind] == nil {
+			return fmt.Errorf("unknown kind of NPC %q", nd.Kind)
+		}
+		if rooms[nd.Room] == nil {
+			return fmt.Errorf("NPC %v: unknown room %q", nd.Kind, nd.Room)
+		}
+		if npcs[nd.id()] {
+			return fmt.Errorf("duplicate NPC id %q", nd.id())
+		}
+		npcs[nd.id()] = true
+		for _, id := range nd.Objects {
+			if !objects[id] {
+				return fmt.Errorf("NPC %v: carries unknown object %q", nd.id(), id)
+			}
+		}
+	}
+	return nil
+}
+
+// an object as described, without the parts referring to other objects
+func newObject(od *ObjectDef) *Object {
+	obj := &Object{
+		id:         od.ID,
+		name:       od.Name,
+		desc:       od.Desc,
+		adjectives: od.Adjectives,
+		aliases:    od.Aliases,
+		openable:   od.Openable,
+		open:       od.Open,
+		locked:     od.Locked,
+		narrow:     od.Narrow,
+		fixture:    od.Fixture,
+		carryable:  od.Carryable,
+		creature:   od.Creature,
+		capacity:   od.Capacity,
+		surface:    od.Surface,
+		light:      od.Light,
+		lit:        od.Lit,
+		fuel:       od.Fuel,
+		damage:     od.Damage,
+	}
+	if od.Fuel == 0 {
+		obj.fuel = -1
+	}
+	return obj
+}
+
+// Build a fresh world "instance" from the definition
+func (d *WorldDef) Build() *World {
+	world := &World{def: d, rooms: map[string]*Room{}, objects: map[string]*Object{}}
+	// objects are items, furniture etc.
+	for i, od := range d.Objects {
+		world.objects[od.ID] = newObject(&d.Objects[i])
+	}
+	for _, od := range d.Objects {
+		obj := world.objects[od.ID]
+		obj.AddObject(world.findObjects(od.Contents)...)
+		obj.key = world.objects[od.Key]
+		for verb, effect := range od.Verbs {
+			if obj.verbs == nil {
+				obj.verbs = map[string]func(*Object, *Player){}
+			}
+			obj.verbs[verb] = world.effectFunc(effect)
+		}
+	}
+	// rooms:
+	for _, rd := range d.Rooms {
+		room := &Room{id: rd.ID, name: rd.Name, desc: rd.Desc, dark: rd.Dark, outdoors: rd.Outdoors}
+		room.AddObject(world.findObjects(rd.Objects)...)
+		world.rooms[rd.ID] = room
+	}
+	// connections:
+	for _, rd := range d.Rooms {
+		room := world.rooms[rd.ID]
+		for dir, ed := range rd.Exits {
+			exit := room.SetExit(dir, world.rooms[ed.To])
+			exit.hidden, exit.blocked = ed.Hidden, ed.Blocked
+		}
+	}
+	for _, od := range d.Objects {
+		for id, dirs := range od.Door {
+			for _, dir := range dirs {
+				world.rooms[id].SetDoor(dir, world.objects[od.ID])
+			}
+		}
+	}
+	world.start = world.rooms[d.Start]
+	for _, nd := range d.NPCs {
+		npc := npcKinds[nd.Kind](nd.id(), world.rooms[nd.Room])
+		npc.Object().AddObject(world.findObjects(nd.Objects)...)
+		world.npcs = append(world.npcs, npc)
+	}
+	return world
+}
+
+func (d *NPCDef) id() string {
+	if d.ID == "" {
+		return d.Kind
+	}
+	return d.ID
+}
+
+// collect objects by id, ids unknown to this world are skipped.
+func (w *World) findObjects(ids []string) []*Object {
+	objs := []*Object{}
+	for _, id := range ids {
+		if obj := w.objects[id]; obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// turn a scripted effect into a verb callback of an object
+func (w *World) effectFunc(effect EffectDef) func(*Object, *Player) {
+	reveal, give := w.findObjects(effect.Reveal), w.findObjects(effect.Give)
+	return func(object *Object, player *Player) {
+		if effect.Once && object.open {
+			player.Println(effect.Else)
+			return
+		}
+		if effect.Say != "" {
+			player.Println(effect.Say)
+		}
+		if effect.Desc != "" {
+			object.desc = effect.Desc
+		}
+		if effect.RoomDesc != "" {
+			player.room.desc = effect.RoomDesc
+		}
+		player.room.AddObject(reveal...)
+		player.room.Discover(effect.Discover...)
+		player.AddObject(give...)
+		if effect.Once {
+			object.open = true
+		}
+		if effect.Points > 0 {
+			player.GivePoints(effect.Points)
+		}
+	}
+}